@@ -3,35 +3,42 @@ package basicgorm
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"golang.org/x/crypto/bcrypt"
 )
 
 type SqlExecSingle struct {
-	ob     []map[string]interface{} //datos para observación
-	data   []map[string]interface{} //datos para insertar o actualizar o eliminar
-	query  []map[string]interface{}
-	schema Schema
-	action string
+	ob       []map[string]interface{} //datos para observación
+	data     []map[string]interface{} //datos para insertar o actualizar o eliminar
+	query    []map[string]interface{}
+	schema   Schema
+	action   string
+	returned [][]map[string]interface{} //filas retornadas por RETURNING por cada registro
+	conflict []string                   //columnas del ON CONFLICT utilizadas por Upsert, por defecto los campos PrimaryKey
 }
 
 type SqlExecMultiple struct {
 	tx          *sql.Tx
-	database    string
+	config      QConfig
 	transaction []*Transaction
 }
 
 type Transaction struct {
-	ob     []map[string]interface{} //datos para observación
-	data   []map[string]interface{} //datos para insertar o actualizar o eliminar
-	query  []map[string]interface{}
-	schema Schema
-	action string
+	ob       []map[string]interface{} //datos para observación
+	data     []map[string]interface{} //datos para insertar o actualizar o eliminar
+	query    []map[string]interface{}
+	schema   Schema
+	action   string
+	returned [][]map[string]interface{} //filas retornadas por RETURNING por cada registro
+	conflict []string                   //columnas del ON CONFLICT utilizadas por Upsert, por defecto los campos PrimaryKey
 }
 
 /*
@@ -53,11 +60,13 @@ func (sq *SqlExecSingle) New(s Schema, datos ...map[string]interface{}) *SqlExec
 /*
 Valida los datos para insertar y crea el query para insertar
 
+	Parámetros
+		* returning {...string}: opcional, columnas a retornar (RETURNING) luego de la ejecución, ver GetReturning
 	Return
 		- (error): retorna errores ocurridos en la validación
 */
-func (sq *SqlExecSingle) Insert() error {
-	sqlExec, data_insert, err := _insert(sq.schema.GetTableName(), sq.ob, sq.schema.GetSchemaInsert())
+func (sq *SqlExecSingle) Insert(returning ...string) error {
+	sqlExec, data_insert, err := _insert(sq.schema.GetTableName(), sq.ob, sq.schema.GetSchemaInsert(), returning)
 	if err != nil {
 		return err
 	}
@@ -70,11 +79,13 @@ func (sq *SqlExecSingle) Insert() error {
 /*
 Valida los datos para actualizar y crea el query para actualizar
 
+	Parámetros
+		* returning {...string}: opcional, columnas a retornar (RETURNING) luego de la ejecución, ver GetReturning
 	Return
 		- (error): retorna errores ocurridos en la validación
 */
-func (sq *SqlExecSingle) Update() error {
-	sqlExec, data_update, err := _update(sq.schema.GetTableName(), sq.ob, sq.schema.GetSchemaUpdate())
+func (sq *SqlExecSingle) Update(returning ...string) error {
+	sqlExec, data_update, err := _update(sq.schema.GetTableName(), sq.ob, sq.schema.GetSchemaUpdate(), returning)
 	if err != nil {
 		return err
 	}
@@ -87,11 +98,13 @@ func (sq *SqlExecSingle) Update() error {
 /*
 Valida los datos para Eliminar y crea el query para Eliminar
 
+	Parámetros
+		* returning {...string}: opcional, columnas a retornar (RETURNING) luego de la ejecución, ver GetReturning
 	Return
 		- (error): retorna errores ocurridos en la validación
 */
-func (sq *SqlExecSingle) Delete() error {
-	sqlExec, data_delete, err := _delete(sq.schema.GetTableName(), sq.ob, sq.schema.GetSchemaDelete())
+func (sq *SqlExecSingle) Delete(returning ...string) error {
+	sqlExec, data_delete, err := _delete(sq.schema.GetTableName(), sq.ob, sq.schema.GetSchemaDelete(), returning)
 	if err != nil {
 		return err
 	}
@@ -101,6 +114,42 @@ func (sq *SqlExecSingle) Delete() error {
 	return nil
 }
 
+/*
+OnConflict establece las columnas del conflicto (ON CONFLICT) que utilizara Upsert en lugar de los campos PrimaryKey,
+las columnas deben de tener un índice único o ser la llave primaria de la tabla
+
+	Parámetros
+		* columns {...string}: columnas del conflicto
+	Return
+		- (*SqlExecSingle) retorna  puntero *SqlExecSingle struct
+*/
+func (sq *SqlExecSingle) OnConflict(columns ...string) *SqlExecSingle {
+	sq.conflict = columns
+	return sq
+}
+
+/*
+Valida los datos para insertar y crea el query para insertar o actualizar si el registro ya existe (INSERT ... ON CONFLICT),
+el conflicto se determina por los campos PrimaryKey del esquema (o las columnas de OnConflict) y solo se actualizan
+los campos con Update: true enviados en el registro (los valores Default solo se utilizan al insertar),
+si ningún campo se puede actualizar el registro existente se conserva (DO NOTHING)
+
+	Parámetros
+		* returning {...string}: opcional, columnas a retornar (RETURNING) luego de la ejecución, los registros conservados con DO NOTHING no retornan filas
+	Return
+		- (error): retorna errores ocurridos en la validación
+*/
+func (sq *SqlExecSingle) Upsert(returning ...string) error {
+	sqlExec, data_upsert, err := _upsert(sq.schema.GetTableName(), sq.ob, sq.schema.GetSchemaInsert(), sq.conflict, returning)
+	if err != nil {
+		return err
+	}
+	sq.query = sqlExec
+	sq.data = data_upsert
+	sq.action = "UPSERT"
+	return nil
+}
+
 /*
 Retorna los datos que se enviaron o enviaran para ser insertados, modificados o eliminados
 
@@ -111,6 +160,54 @@ func (sq *SqlExecSingle) GetData() []map[string]interface{} {
 	return sq.data
 }
 
+/*
+GetQuery retorna las sentencias SQL generadas por Insert, Update, Delete o Upsert en el orden en que se ejecutaran,
+las columnas siguen el orden de los campos del esquema
+
+	Return
+		- []string
+*/
+func (sq *SqlExecSingle) GetQuery() []string {
+	return getQuerySql(sq.query)
+}
+
+/*
+GetArgs retorna los argumentos de cada sentencia SQL generada, en el mismo orden que GetQuery
+
+	Return
+		- [][]interface{}
+*/
+func (sq *SqlExecSingle) GetArgs() [][]interface{} {
+	return getQueryArgs(sq.query)
+}
+
+/*
+GetReturning retorna las filas obtenidas por RETURNING en la ultima ejecución, una lista de filas por cada registro
+en el mismo orden en que se enviaron los datos (un UPDATE o DELETE puede afectar varias filas por registro)
+
+	Ejemplo
+		crud.New(schema, datos...).Insert("id_venta", "f_regi")
+		err := crud.Exec("mi_database")
+		id := crud.GetReturning()[0][0]["id_venta"]
+	Return
+		- [][]map[string]interface{}
+*/
+func (sq *SqlExecSingle) GetReturning() [][]map[string]interface{} {
+	return sq.returned
+}
+
+/*
+ScanReturning copia las filas obtenidas por RETURNING en dest relacionando las columnas con la etiqueta db, igual que Querys.Scan
+
+	Parámetros
+		* dest {interface{}}: puntero a struct (primera fila) o puntero a slice de structs (todas las filas de todos los registros)
+	Return
+		- (error): retorna errores ocurridos al asignar los valores, sql.ErrNoRows si no se retornaron filas
+*/
+func (sq *SqlExecSingle) ScanReturning(dest interface{}) error {
+	return scanReturning(sq.returned, dest)
+}
+
 /*
 Ejecuta el query
 
@@ -118,21 +215,56 @@ Ejecuta el query
 		- returns {error}: retorna errores ocurridos durante la ejecución
 */
 func (sq *SqlExecSingle) Exec(database string, params ...bool) error {
-	cnn, err := Connection(database)
+	return sq.ExecConfig(QConfig{Database: database}, params...)
+}
+
+/*
+Ejecuta el query utilizando la configuración de conexión recibida
+
+	Parámetros
+		* config {QConfig}: configuración de la conexión (Cloud, Database o Config)
+	Return
+		- returns {error}: retorna errores ocurridos durante la ejecución
+*/
+func (sq *SqlExecSingle) ExecConfig(config QConfig, params ...bool) error {
+	return sq.ExecConfigContext(context.Background(), config, params...)
+}
+
+/*
+Ejecuta el query propagando la cancelación y el tiempo limite del contexto
+
+	Parámetros
+		* ctx {context.Context}: contexto de la ejecución
+		* database {string}: base de datos
+	Return
+		- returns {error}: retorna errores ocurridos durante la ejecución, si fue cancelada contiene ErrQueryCanceled
+*/
+func (sq *SqlExecSingle) ExecContext(ctx context.Context, database string, params ...bool) error {
+	return sq.ExecConfigContext(ctx, QConfig{Database: database}, params...)
+}
+
+/*
+Ejecuta el query con la configuración de conexión recibida propagando la cancelación y el tiempo limite del contexto
+
+	Parámetros
+		* ctx {context.Context}: contexto de la ejecución
+		* config {QConfig}: configuración de la conexión (Cloud, Database, Config o Timeout)
+	Return
+		- returns {error}: retorna errores ocurridos durante la ejecución, si fue cancelada contiene ErrQueryCanceled
+*/
+func (sq *SqlExecSingle) ExecConfigContext(ctx context.Context, config QConfig, params ...bool) error {
+	ctx, cancel := withTimeout(ctx, config.Timeout)
+	defer cancel()
+	cnn, cache, err := getPool(ctx, config)
 	if err != nil {
 		return err
 	}
-	ctx := context.Background()
-	err_cnn := cnn.PingContext(ctx)
-	if err_cnn != nil {
-		return errors.New(fmt.Sprint("Error Sql PING: ", err_cnn))
-	}
 	cross := false
 	if len(params) == 1 {
 		cross = params[0]
 	}
+	sq.returned = nil
 	dataExec := sq.query
-	defer cnn.Close()
 	for _, item := range dataExec {
 		sqlPre := item["sqlPreparate"].(string)
 		if cross {
@@ -141,14 +273,17 @@ func (sq *SqlExecSingle) Exec(database string, params ...bool) error {
 			}
 		}
 		// fmt.Println("PREPARED: ", sqlPre)
-		stmt, err_prepare := cnn.Prepare(sqlPre)
+		stmt, release, err_prepare := itemCache(cache, item).prepare(ctx, cnn, sqlPre)
 		if err_prepare != nil {
-			return fmt.Errorf("error sql prepare: %s ", err_prepare.Error())
+			return execError(ctx, CodeSQLPrepare, nil, err_prepare)
 		}
-		valuesExec := item["valuesExec"].([]interface{})
-		_, err_exec := stmt.Exec(valuesExec...)
+		returned, err_exec := execStmt(ctx, stmt, item)
+		release()
 		if err_exec != nil {
-			return fmt.Errorf("error sql %s: %s", sq.action, err_exec.Error())
+			return execError(ctx, CodeSQLExec, map[string]interface{}{"action": sq.action}, err_exec)
+		}
+		if hasReturning(item) {
+			sq.returned = append(sq.returned, returned)
 		}
 	}
 	return nil
@@ -159,11 +294,15 @@ Crea una nueva instancia de SqlExecMultiple con el nombre de la base de datos pr
 
 	Parámetros
 	  * name {string}: database
+	  * config {Config}: opcional, datos de conexión, si no se envía se utiliza la configuración por defecto
 	Return
 	  - (*SqlExecMultiple) retorna  puntero *SqlExecMultiple struct
 */
-func (sq *SqlExecMultiple) New(database string) *SqlExecMultiple {
-	sq.database = database
+func (sq *SqlExecMultiple) New(database string, config ...Config) *SqlExecMultiple {
+	sq.config = QConfig{Database: database}
+	if len(config) > 0 {
+		sq.config.Config = &config[0]
+	}
 	// sq.transaction = make(map[string]*Transaction)
 	return sq
 }
@@ -245,11 +384,13 @@ func (sq *SqlExecMultiple) SetSqlSingle(s SqlExecSingle) (*Transaction, error) {
 		return nil, errors.New("datos de " + s.schema.GetTableName() + " aun no han sido procesados")
 	}
 	sq.transaction = append(sq.transaction, &Transaction{
-		ob:     s.ob,
-		data:   s.data,
-		schema: s.schema,
-		action: s.action,
-		query:  s.query,
+		ob:       s.ob,
+		data:     s.data,
+		schema:   s.schema,
+		action:   s.action,
+		query:    s.query,
+		returned: s.returned,
+		conflict: s.conflict,
 	})
 	return sq.transaction[key], nil
 }
@@ -262,28 +403,37 @@ Ejecuta el query
 		- (error): retorna errores ocurridos durante la ejecución
 */
 func (sq *SqlExecMultiple) Exec(params ...bool) error {
-	cnn, err := Connection(sq.database)
+	return sq.ExecContext(context.Background(), params...)
+}
+
+/*
+*
+Ejecuta el query en una sola transacción propagando la cancelación y el tiempo limite del contexto,
+si el contexto se cancela la transacción se revierte
+
+	Parámetros
+		* ctx {context.Context}: contexto de la ejecución
+	Return
+		- (error): retorna errores ocurridos durante la ejecución, si fue cancelada contiene ErrQueryCanceled
+*/
+func (sq *SqlExecMultiple) ExecContext(ctx context.Context, params ...bool) error {
+	cnn, cache, err := getPool(ctx, sq.config)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
 	tx, err := cnn.BeginTx(ctx, nil)
 	if err != nil {
-		return fmt.Errorf("error sql tx: %s ", err.Error())
+		return execError(ctx, CodeSQLTx, nil, err)
 	}
 
-	err = cnn.PingContext(ctx)
-	if err != nil {
-		return errors.New(fmt.Sprint("Error Sql PING: ", err))
-	}
 	cross := false
 	if len(params) == 1 {
 		cross = params[0]
 	}
-	defer cnn.Close()
 
 	for _, t := range sq.transaction {
+		t.returned = nil
 		for _, item := range t.query {
 			sqlPre := item["sqlPreparate"].(string)
 			if cross {
@@ -291,12 +441,14 @@ func (sq *SqlExecMultiple) Exec(params ...bool) error {
 					sqlPre = Query_Cross_Update(sqlPre)
 				}
 			}
-			valuesExec := item["valuesExec"].([]interface{})
-			// fmt.Println(sqlPre, valuesExec)
-			_, err := tx.Exec(sqlPre, valuesExec...)
+			// fmt.Println(sqlPre, item["valuesExec"])
+			returned, err := execTxItem(ctx, cache, cnn, tx, sqlPre, item)
 			if err != nil {
 				tx.Rollback()
-				return fmt.Errorf("error sql %s: %s", t.action, err.Error())
+				return execError(ctx, CodeSQLExec, map[string]interface{}{"action": t.action}, err)
+			}
+			if hasReturning(item) {
+				t.returned = append(t.returned, returned)
 			}
 		}
 	}
@@ -304,14 +456,14 @@ func (sq *SqlExecMultiple) Exec(params ...bool) error {
 	//Commit para confirmar la transacción
 	err = tx.Commit()
 	if err != nil {
-		return fmt.Errorf("error sql ping: %s", err.Error())
+		return execError(ctx, CodeSQLCommit, nil, err)
 	}
 
 	return nil
 }
 
-func (t *Transaction) Insert() error {
-	sqlExec, data_insert, err := _insert(t.schema.GetTableName(), t.ob, t.schema.GetSchemaInsert())
+func (t *Transaction) Insert(returning ...string) error {
+	sqlExec, data_insert, err := _insert(t.schema.GetTableName(), t.ob, t.schema.GetSchemaInsert(), returning)
 	if err != nil {
 		return err
 	}
@@ -321,8 +473,8 @@ func (t *Transaction) Insert() error {
 	return nil
 }
 
-func (t *Transaction) Update() error {
-	sqlExec, data_update, err := _update(t.schema.GetTableName(), t.ob, t.schema.GetSchemaUpdate())
+func (t *Transaction) Update(returning ...string) error {
+	sqlExec, data_update, err := _update(t.schema.GetTableName(), t.ob, t.schema.GetSchemaUpdate(), returning)
 	if err != nil {
 		return err
 	}
@@ -332,8 +484,8 @@ func (t *Transaction) Update() error {
 	return nil
 }
 
-func (t *Transaction) Delete() error {
-	sqlExec, data_delete, err := _delete(t.schema.GetTableName(), t.ob, t.schema.GetSchemaDelete())
+func (t *Transaction) Delete(returning ...string) error {
+	sqlExec, data_delete, err := _delete(t.schema.GetTableName(), t.ob, t.schema.GetSchemaDelete(), returning)
 	if err != nil {
 		return err
 	}
@@ -343,38 +495,84 @@ func (t *Transaction) Delete() error {
 	return nil
 }
 
+/** OnConflict establece las columnas del conflicto que utilizara Upsert, ver SqlExecSingle.OnConflict */
+func (t *Transaction) OnConflict(columns ...string) *Transaction {
+	t.conflict = columns
+	return t
+}
+
+/** Upsert valida los datos y crea el query para insertar o actualizar si el registro ya existe, ver SqlExecSingle.Upsert */
+func (t *Transaction) Upsert(returning ...string) error {
+	sqlExec, data_upsert, err := _upsert(t.schema.GetTableName(), t.ob, t.schema.GetSchemaInsert(), t.conflict, returning)
+	if err != nil {
+		return err
+	}
+	t.query = sqlExec
+	t.data = data_upsert
+	t.action = "UPSERT"
+	return nil
+}
+
 func (t *Transaction) GetData() []map[string]interface{} {
 	return t.data
 }
 
+/** GetQuery retorna las sentencias SQL generadas para la transacción, ver SqlExecSingle.GetQuery */
+func (t *Transaction) GetQuery() []string {
+	return getQuerySql(t.query)
+}
+
+/** GetArgs retorna los argumentos de cada sentencia SQL generada, ver SqlExecSingle.GetArgs */
+func (t *Transaction) GetArgs() [][]interface{} {
+	return getQueryArgs(t.query)
+}
+
+/** GetReturning retorna las filas obtenidas por RETURNING por cada registro de la transacción, ver SqlExecSingle.GetReturning */
+func (t *Transaction) GetReturning() [][]map[string]interface{} {
+	return t.returned
+}
+
+/** ScanReturning copia las filas obtenidas por RETURNING en dest, ver SqlExecSingle.ScanReturning */
+func (t *Transaction) ScanReturning(dest interface{}) error {
+	return scanReturning(t.returned, dest)
+}
+
 func (sq *SqlExecMultiple) ExecTransaction(t *Transaction) error {
-	if sq.tx == nil {
-		cnn, err := Connection(sq.database)
-		if err != nil {
-			return err
-		}
+	return sq.ExecTransactionContext(context.Background(), t)
+}
 
-		ctx := context.Background()
-		sq.tx, err = cnn.BeginTx(ctx, nil)
-		if err != nil {
-			return fmt.Errorf("error sql tx: %s ", err.Error())
-		}
+/*
+ExecTransactionContext ejecuta la transacción propagando la cancelación y el tiempo limite del contexto,
+la primera llamada abre la transacción con el contexto recibido, si este se cancela la transacción se revierte
 
-		err = cnn.PingContext(ctx)
+	Parámetros
+		* ctx {context.Context}: contexto de la ejecución
+		* t {*Transaction}: transacción ya procesada
+	Return
+		- (error): retorna errores ocurridos durante la ejecución, si fue cancelada contiene ErrQueryCanceled
+*/
+func (sq *SqlExecMultiple) ExecTransactionContext(ctx context.Context, t *Transaction) error {
+	cnn, cache, err := getPool(ctx, sq.config)
+	if err != nil {
+		return err
+	}
+	if sq.tx == nil {
+		sq.tx, err = cnn.BeginTx(ctx, nil)
 		if err != nil {
-			return errors.New(fmt.Sprint("Error Sql PING: ", err))
+			return execError(ctx, CodeSQLTx, nil, err)
 		}
-
-		defer cnn.Close()
 	}
 
+	t.returned = nil
 	for _, item := range t.query {
 		sqlPre := item["sqlPreparate"].(string)
-		valuesExec := item["valuesExec"].([]interface{})
-		_, err := sq.tx.Exec(sqlPre, valuesExec...)
+		returned, err := execTxItem(ctx, cache, cnn, sq.tx, sqlPre, item)
 		if err != nil {
 			sq.tx.Rollback()
-			return fmt.Errorf("error sql %s: %s", t.action, err.Error())
+			return execError(ctx, CodeSQLExec, map[string]interface{}{"action": t.action}, err)
+		}
+		if hasReturning(item) {
+			t.returned = append(t.returned, returned)
 		}
 	}
 
@@ -382,51 +580,209 @@ func (sq *SqlExecMultiple) ExecTransaction(t *Transaction) error {
 }
 
 func (sq *SqlExecMultiple) Commit() error {
+	return sq.CommitContext(context.Background())
+}
+
+/*
+CommitContext confirma la transacción abierta por ExecTransactionContext, si el contexto ya fue cancelado
+la transacción se revierte
+
+	Parámetros
+		* ctx {context.Context}: contexto de la ejecución
+	Return
+		- (error): retorna errores ocurridos al confirmar, si fue cancelada contiene ErrQueryCanceled
+*/
+func (sq *SqlExecMultiple) CommitContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		sq.tx.Rollback()
+		return execError(ctx, CodeSQLCommit, nil, err)
+	}
 	err := sq.tx.Commit()
 	if err != nil {
-		return fmt.Errorf("error sql ping: %s", err.Error())
+		return execError(ctx, CodeSQLCommit, nil, err)
 	}
 	return nil
 }
 
-func _insert(table string, data []map[string]interface{}, schema []Fields) ([]map[string]interface{}, []map[string]interface{}, error) {
-	length := len(data)
-	if length > 0 {
-		var sqlExec = make([]map[string]interface{}, 0)
-		var data_insert []map[string]interface{}
+/** Rollback revierte la transacción abierta por ExecTransactionContext o utilizada por BulkCopy */
+func (sq *SqlExecMultiple) Rollback() error {
+	if sq.tx == nil {
+		return nil
+	}
+	err := sq.tx.Rollback()
+	if err != nil {
+		return execError(context.Background(), CodeSQLTx, nil, err)
+	}
+	return nil
+}
+
+func getQuerySql(query []map[string]interface{}) []string {
+	var result []string
+	for _, item := range query {
+		result = append(result, item["sqlPreparate"].(string))
+	}
+	return result
+}
+
+func getQueryArgs(query []map[string]interface{}) [][]interface{} {
+	var result [][]interface{}
+	for _, item := range query {
+		result = append(result, item["valuesExec"].([]interface{}))
+	}
+	return result
+}
+
+/** cantidad máxima de parámetros ($n) que PostgreSQL admite en una sola sentencia */
+const maxParams = 65535
+
+/*
+_insert valida los registros y genera sentencias INSERT de varias filas (VALUES(...), (...)), todas las filas utilizan
+las mismas columnas en el orden del esquema, los campos que no se enviaron en una fila toman el valor DEFAULT de la tabla.
+Los registros se agrupan para no superar la cantidad máxima de parámetros por sentencia, con RETURNING se genera una sentencia por registro.
+*/
+func _insert(table string, data []map[string]interface{}, schema []Fields, returning []string) ([]map[string]interface{}, []map[string]interface{}, error) {
+	if err := checkSchemaIdentifiers(table, schema); err != nil {
+		return nil, nil, err
+	}
+	sqlReturning, err := returningClause(returning)
+	if err != nil {
+		return nil, nil, err
+	}
+	data_insert, err := _checkInsertRows(schema, data)
+	if err != nil {
+		return nil, nil, err
+	}
+	columns := _insertColumns(schema, data_insert)
+	if len(columns) == 0 {
+		return nil, nil, catalogError(context.Background(), CodeNoInsertData)
+	}
+	size := maxParams / len(columns)
+	if sqlReturning != "" {
+		/** PostgreSQL no garantiza el orden de las filas de RETURNING en un INSERT de varias filas, con un registro por sentencia cada resultado corresponde a su registro */
+		size = 1
+	}
+	var sqlExec = make([]map[string]interface{}, 0)
+	for start := 0; start < len(data_insert); start += size {
+		end := min(start+size, len(data_insert))
+		sqlExec = append(sqlExec, _insertValues(table, columns, data_insert[start:end], sqlReturning))
+	}
+	return sqlExec, data_insert, nil
+}
+
+/** valida cada registro con _checkInsertSchema, retorna el error del primer registro invalido */
+func _checkInsertRows(schema []Fields, data []map[string]interface{}) ([]map[string]interface{}, error) {
+	if len(data) <= 0 {
+		return nil, catalogError(context.Background(), CodeNoInsertData)
+	}
+	var data_insert []map[string]interface{}
+	for _, item := range data {
+		preArray, err := _checkInsertSchema(schema, item)
+		if err != nil {
+			return nil, err
+		}
+		data_insert = append(data_insert, preArray)
+	}
+	return data_insert, nil
+}
 
+/** retorna las columnas que tienen valor en al menos un registro, en el orden del esquema */
+func _insertColumns(schema []Fields, data []map[string]interface{}) []string {
+	var columns []string
+	for _, field := range schema {
 		for _, item := range data {
-			preArray, err := _checkInsertSchema(schema, item)
-			if err == nil {
-				data_insert = append(data_insert, preArray)
-				var column []string
-				var values []string
-				var i int
-				var valuesExec []interface{}
-				char := "$"
-				for k, v := range preArray {
-					i++
-					column = append(column, k)
-					values = append(values, fmt.Sprintf("%s%d", char, i))
-					valuesExec = append(valuesExec, v)
-				}
+			if _, ok := item[field.Name]; ok {
+				columns = append(columns, field.Name)
+				break
+			}
+		}
+	}
+	return columns
+}
 
-				sqlPreparate := fmt.Sprintf("INSERT INTO %s (%s) VALUES(%s)", table, strings.Join(column, ", "), strings.Join(values, ", "))
-				sqlExec = append(sqlExec, map[string]interface{}{
-					"sqlPreparate": sqlPreparate,
-					"valuesExec":   valuesExec,
-				})
-			} else {
-				return nil, nil, err
+/** genera la sentencia INSERT para los registros, los campos sin valor en un registro se envían como DEFAULT */
+func _insertValues(table string, columns []string, data []map[string]interface{}, sqlReturning string) map[string]interface{} {
+	var rows []string
+	var valuesExec []interface{}
+	char := "$"
+	for _, item := range data {
+		var values []string
+		for _, column := range columns {
+			v, ok := item[column]
+			if !ok {
+				values = append(values, "DEFAULT")
+				continue
 			}
+			valuesExec = append(valuesExec, v)
+			values = append(values, fmt.Sprintf("%s%d", char, len(valuesExec)))
 		}
-		return sqlExec, data_insert, nil
-	} else {
-		return nil, nil, errors.New("no existen datos para insertar")
+		rows = append(rows, strings.Join(values, ", "))
+	}
+	sqlPreparate := fmt.Sprintf("INSERT INTO %s (%s) VALUES(%s)%s", table, strings.Join(columns, ", "), strings.Join(rows, "), ("), sqlReturning)
+	return map[string]interface{}{
+		"sqlPreparate": sqlPreparate,
+		"valuesExec":   valuesExec,
+		"returning":    sqlReturning != "",
+		"records":      len(data),
+	}
+}
+
+func _upsert(table string, data []map[string]interface{}, schema []Fields, conflict []string, returning []string) ([]map[string]interface{}, []map[string]interface{}, error) {
+	if len(conflict) == 0 {
+		for _, item := range schema {
+			if item.PrimaryKey {
+				conflict = append(conflict, item.Name)
+			}
+		}
+	}
+	if len(conflict) == 0 {
+		return nil, nil, errors.New("no existen campos PrimaryKey para determinar el conflicto, utilice OnConflict")
+	}
+	for _, column := range conflict {
+		if err := CheckIdentifier(column); err != nil {
+			return nil, nil, err
+		}
+	}
+	sqlReturning, err := returningClause(returning)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := checkSchemaIdentifiers(table, schema); err != nil {
+		return nil, nil, err
+	}
+	data_upsert, err := _checkInsertRows(schema, data)
+	if err != nil {
+		return nil, nil, err
 	}
+	/** una sentencia por registro, un mismo conflicto no se puede actualizar dos veces en una sentencia */
+	var sqlExec = make([]map[string]interface{}, 0)
+	for i := range data_upsert {
+		item := _insertValues(table, _insertColumns(schema, data_upsert[i:i+1]), data_upsert[i:i+1], "")
+		sqlExec = append(sqlExec, item)
+		/** solo se actualizan los campos enviados, los valores Default no deben de sobrescribir el registro existente */
+		var setters []string
+		for _, field := range schema {
+			if field.Update && data[i][field.Name] != nil {
+				setters = append(setters, fmt.Sprintf("%s = EXCLUDED.%s", field.Name, field.Name))
+			}
+		}
+		action := "DO NOTHING"
+		if len(setters) > 0 {
+			action = "DO UPDATE SET " + strings.Join(setters, ", ")
+		}
+		item["sqlPreparate"] = fmt.Sprintf("%s ON CONFLICT (%s) %s%s", item["sqlPreparate"], strings.Join(conflict, ", "), action, sqlReturning)
+		item["returning"] = sqlReturning != ""
+	}
+	return sqlExec, data_upsert, nil
 }
 
-func _update(table string, data []map[string]interface{}, schema []Fields) ([]map[string]interface{}, []map[string]interface{}, error) {
+func _update(table string, data []map[string]interface{}, schema []Fields, returning []string) ([]map[string]interface{}, []map[string]interface{}, error) {
+	if err := checkSchemaIdentifiers(table, schema); err != nil {
+		return nil, nil, err
+	}
+	sqlReturning, err := returningClause(returning)
+	if err != nil {
+		return nil, nil, err
+	}
 	length := len(data)
 
 	if length > 0 {
@@ -461,38 +817,54 @@ func _update(table string, data []map[string]interface{}, schema []Fields) ([]ma
 			var i uint64
 			var valuesExec []interface{}
 			char := "$"
-			for k, v := range preArray {
+			for _, field := range schema {
+				v, ok := preArray[field.Name]
+				if !ok {
+					continue
+				}
 				i++
-				setters = append(setters, fmt.Sprintf("%s= %s%d", k, char, i))
+				setters = append(setters, fmt.Sprintf("%s= %s%d", field.Name, char, i))
 				valuesExec = append(valuesExec, v)
 			}
 
 			if length_where > 0 {
 				length_newMapWhere := len(preArray_where)
 				var wheres []string
-				for k, v := range preArray_where {
+				for _, field := range schema {
+					v, ok := preArray_where[field.Name]
+					if !ok {
+						continue
+					}
 					i++
-					wheres = append(wheres, k+" = "+char+strconv.FormatUint(i, 10))
+					wheres = append(wheres, field.Name+" = "+char+strconv.FormatUint(i, 10))
 					valuesExec = append(valuesExec, v)
 				}
 				if length_newMapWhere > 0 {
 					sqlWherePreparateUpdate = "WHERE " + strings.Join(wheres, " AND ")
 				}
 			}
-			sqlPreparate := fmt.Sprintf("UPDATE %s SET %s %s", table, strings.Join(setters, ", "), sqlWherePreparateUpdate)
+			sqlPreparate := fmt.Sprintf("UPDATE %s SET %s %s%s", table, strings.Join(setters, ", "), sqlWherePreparateUpdate, sqlReturning)
 			sqlExec = append(sqlExec, map[string]interface{}{
 				"sqlPreparate": sqlPreparate,
 				"valuesExec":   valuesExec,
+				"returning":    sqlReturning != "",
 			})
 
 		}
 		return sqlExec, data_update, nil
 	} else {
-		return nil, nil, errors.New("no existen datos para actualizar")
+		return nil, nil, catalogError(context.Background(), CodeNoUpdateData)
 	}
 }
 
-func _delete(table string, data []map[string]interface{}, schema []Fields) ([]map[string]interface{}, []map[string]interface{}, error) {
+func _delete(table string, data []map[string]interface{}, schema []Fields, returning []string) ([]map[string]interface{}, []map[string]interface{}, error) {
+	if err := checkSchemaIdentifiers(table, schema); err != nil {
+		return nil, nil, err
+	}
+	sqlReturning, err := returningClause(returning)
+	if err != nil {
+		return nil, nil, err
+	}
 	length := len(data)
 
 	if length > 0 {
@@ -506,7 +878,7 @@ func _delete(table string, data []map[string]interface{}, schema []Fields) ([]ma
 			}
 
 			data_delete = append(data_delete, preArray)
-			var lineSqlExec = make(map[string]interface{}, 2)
+			var lineSqlExec = make(map[string]interface{}, 3)
 			sqlWherePreparateDelete := ""
 			var i int
 			var p uint64
@@ -516,7 +888,12 @@ func _delete(table string, data []map[string]interface{}, schema []Fields) ([]ma
 				sqlWherePreparateDelete += " WHERE "
 			}
 			char := "$"
-			for k, v := range preArray {
+			for _, field := range schema {
+				v, ok := preArray[field.Name]
+				if !ok {
+					continue
+				}
+				k := field.Name
 				p++
 				if i+1 < length_newMap {
 					// sqlWherePreparateUpdate += fmt.Sprintf("%s = '%s' AND ", ke, va)
@@ -529,141 +906,128 @@ func _delete(table string, data []map[string]interface{}, schema []Fields) ([]ma
 				i++
 			}
 
-			sqlPreparate := fmt.Sprintf("DELETE FROM %s %s", table, sqlWherePreparateDelete)
+			sqlPreparate := fmt.Sprintf("DELETE FROM %s %s%s", table, sqlWherePreparateDelete, sqlReturning)
 			lineSqlExec["sqlPreparate"] = sqlPreparate
 			lineSqlExec["valuesExec"] = valuesExec
+			lineSqlExec["returning"] = sqlReturning != ""
 			sqlExec = append(sqlExec, lineSqlExec)
 		}
 		return sqlExec, data_delete, nil
 	} else {
-		return nil, nil, errors.New("no existen datos para actualizar")
+		return nil, nil, catalogError(context.Background(), CodeNoUpdateData)
 	}
 }
 
 func _checkInsertSchema(schema []Fields, tabla_map map[string]interface{}) (map[string]interface{}, error) {
-
-	// var err_cont uint64 = 0
-	var err_cont uint
-	var error string
-
+	var errs ValidationErrors
 	data := make(map[string]interface{})
 
 	for _, item := range schema {
 		isNil := tabla_map[item.Name] == nil
 		defaultIsNil := item.Default == nil
 		if !isNil {
-			value := tabla_map[item.Name]
-			new_value, err := strconvDataType(string(item.Type), value)
-			if err != nil {
-				err_cont++
-				error += fmt.Sprintf("%d.- El campo %s %s", err_cont, item.Description, err.Error())
-			}
-			var val interface{}
-			switch item.Type {
-			case "string":
-				val, err = caseString(new_value.(string), item.ValidateType.(TypeStrings))
-			case "float64":
-				val, err = caseFloat(new_value.(float64), item.ValidateType.(TypeFloat64))
-			case "uint64":
-				val, err = caseUint(new_value.(uint64), item.ValidateType.(TypeUint64))
-			case "int64":
-				val, err = caseInt(new_value.(int64), item.ValidateType.(TypeInt64))
-			default:
-				val, err = nil, errors.New("tipo de dato no asignado")
-			}
-			if err == nil {
+			val, rules := _checkValue(item, tabla_map[item.Name], false)
+			if len(rules) == 0 {
 				data[item.Name] = val
 			} else {
-				err_cont++
-				error += fmt.Sprintf("%d.- Se encontró fallas al validar el campo %s \n %s\n", err_cont, item.Description, err.Error())
+				errs.addAll(item, rules)
 			}
 		} else {
 			if !defaultIsNil {
 				data[item.Name] = item.Default
 			} else {
 				if item.Required {
-					err_cont++
-					error += fmt.Sprintf("%d.- El campo %s es Requerido\n", err_cont, item.Description)
+					errs.add(item, CodeRequired, nil)
 				}
 			}
 		}
 
 	}
-	if err_cont > 0 {
-		return nil, errors.New(error)
-	} else {
-		return data, nil
+	if err := errs.err(); err != nil {
+		return nil, err
 	}
+	return data, nil
 }
 
 func _checkUpdate(schema []Fields, tabla_map map[string]interface{}) (map[string]interface{}, error) {
-	var err_cont uint
-	var error string
+	var errs ValidationErrors
 	data := make(map[string]interface{})
 	for _, item := range schema {
 		isNil := tabla_map[item.Name] == nil
 		if !isNil {
 			if item.Update {
-				value := tabla_map[item.Name]
-				new_value, err := strconvDataType(string(item.Type), value)
-				if err != nil {
-					err_cont++
-					error += fmt.Sprintf("%d.- El campo %s %s", err_cont, item.Description, err.Error())
-				}
-				var val interface{}
-				switch item.Type {
-				case "string":
-					if new_value.(string) == "" {
-						if !item.Empty {
-							err_cont++
-							error += fmt.Sprintf("%d.- El campo %s no puede estar vació\n", err_cont, item.Description)
-						}
-					} else {
-						val, err = caseString(new_value.(string), item.ValidateType.(TypeStrings))
-					}
-				case "float64":
-					val, err = caseFloat(new_value.(float64), item.ValidateType.(TypeFloat64))
-				case "uint64":
-					val, err = caseUint(new_value.(uint64), item.ValidateType.(TypeUint64))
-				case "int64":
-					val, err = caseInt(new_value.(int64), item.ValidateType.(TypeInt64))
-				default:
-					val, err = nil, errors.New("tipo de dato no asignado")
-				}
-				if err == nil {
+				val, rules := _checkValue(item, tabla_map[item.Name], true)
+				if len(rules) == 0 {
 					data[item.Name] = val
 				} else {
-					err_cont++
-					error += fmt.Sprintf("%d.- Se encontró fallas al validar el campo %s \n %s\n", err_cont, item.Description, err.Error())
+					errs.addAll(item, rules)
 				}
 			} else {
-				err_cont++
-				error += fmt.Sprintf("%d.- El campo %s no puede ser modificado\n", err_cont, item.Description)
+				errs.add(item, CodeNotUpdatable, nil)
 			}
 		}
 	}
-	if err_cont > 0 {
-		return nil, errors.New(error)
-	} else {
-		return data, nil
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+/*
+_checkValue convierte el valor al tipo del campo y lo valida con las reglas de su ValidateType,
+en una actualización el texto vació se acepta sin validar si el campo lo permite (Empty).
+*/
+func _checkValue(item Fields, value interface{}, update bool) (interface{}, []ValidationError) {
+	new_value, err := strconvDataType(string(item.Type), value)
+	if err != nil {
+		return nil, []ValidationError{rule(CodeInvalidType, nil)}
+	}
+	if text, ok := new_value.(string); ok && update && text == "" {
+		if !item.Empty {
+			return nil, []ValidationError{rule(CodeEmpty, nil)}
+		}
+		return nil, nil
+	}
+	switch item.Type {
+	case "string":
+		return caseString(new_value.(string), item.ValidateType.(TypeStrings))
+	case "float64":
+		return caseFloat(new_value.(float64), item.ValidateType.(TypeFloat64))
+	case "uint64":
+		return caseUint(new_value.(uint64), item.ValidateType.(TypeUint64))
+	case "int64":
+		return caseInt(new_value.(int64), item.ValidateType.(TypeInt64))
+	case "decimal":
+		schema, _ := item.ValidateType.(TypeDecimal)
+		return caseDecimal(new_value.(Numeric), schema)
+	case "json":
+		schema, _ := item.ValidateType.(TypeJSON)
+		return caseJSON(new_value.(json.RawMessage), schema)
+	case "bool":
+		return new_value, nil
+	case "time":
+		schema, _ := item.ValidateType.(TypeTime)
+		return caseTime(new_value, schema)
+	case "bytes":
+		schema, _ := item.ValidateType.(TypeBytes)
+		return caseBytes(new_value.([]byte), schema)
+	default:
+		return nil, []ValidationError{rule(CodeUnsupported, nil)}
 	}
 }
 
 func _checkWhere(schema []Fields, table_where map[string]interface{}) (map[string]interface{}, error) {
-	var err_cont uint
-	var error string
+	var errs ValidationErrors
 	data := make(map[string]interface{})
 	for _, item := range schema {
 		isNil := table_where[item.Name] == nil
 		if !isNil {
 			value := table_where[item.Name]
 			if !item.Where && !item.PrimaryKey {
-				err_cont++
-				error += fmt.Sprintf("%d.- El campo %s no puede ser utilizado de esta forma\n", err_cont, item.Description)
+				errs.add(item, CodeNotFilterable, nil)
 			} else {
-				if value.(string) == "" {
-					err_cont++
-					error += fmt.Sprintf("%d.- El campo %s esta vació verificar\n", err_cont, item.Description)
+				if text, ok := value.(string); ok && text == "" {
+					errs.add(item, CodeEmpty, nil)
 				} else {
 					data[item.Name] = value
 				}
@@ -671,30 +1035,28 @@ func _checkWhere(schema []Fields, table_where map[string]interface{}) (map[strin
 			}
 		} else {
 			if item.PrimaryKey {
-				err_cont++
-				error += fmt.Sprintf("%d.- El campo %s es obligatorio\n", err_cont, item.Description)
+				errs.add(item, CodeRequired, nil)
 			}
 		}
 	}
-	if err_cont > 0 {
-		return nil, errors.New(error)
-	} else {
-		return data, nil
+	if err := errs.err(); err != nil {
+		return nil, err
 	}
+	return data, nil
 }
 
-func caseString(value string, schema TypeStrings) (string, error) {
+func caseString(value string, schema TypeStrings) (interface{}, []ValidationError) {
 	value = strings.TrimSpace(value)
 	if schema.Expr != nil {
 		if !schema.Expr.MatchString(value) {
-			return "", errors.New("- no cumple con las características\n")
+			return nil, []ValidationError{rule(CodeRegex, map[string]interface{}{"expr": schema.Expr.String()})}
 		}
 	}
 
 	if schema.Date {
 		err := CheckDate(value)
 		if err != nil {
-			return "", fmt.Errorf("- %s\n", err.Error())
+			return nil, []ValidationError{rule(CodeDate, map[string]interface{}{"error": err.Error()})}
 		} else {
 			return value, nil
 		}
@@ -714,13 +1076,13 @@ func caseString(value string, schema TypeStrings) (string, error) {
 
 	if schema.Min > 0 {
 		if len(value) < schema.Min {
-			return "", fmt.Errorf("- No Cumple los caracteres mínimos que debe tener (%v)\n", schema.Min)
+			return nil, []ValidationError{rule(CodeMinLength, map[string]interface{}{"min": schema.Min})}
 		}
 	}
 
 	if schema.Max > 0 {
 		if len(value) > schema.Max {
-			return "", fmt.Errorf("- No Cumple los caracteres máximos que debe tener (%v)\n", schema.Max)
+			return nil, []ValidationError{rule(CodeMaxLength, map[string]interface{}{"max": schema.Max})}
 		}
 	}
 
@@ -732,74 +1094,157 @@ func caseString(value string, schema TypeStrings) (string, error) {
 	return value, nil
 }
 
-func caseFloat(value float64, schema TypeFloat64) (float64, error) {
-	error := ""
-	err_cont := 0
+func caseFloat(value float64, schema TypeFloat64) (interface{}, []ValidationError) {
+	var rules []ValidationError
 	if schema.Menor != 0 {
 		if value <= schema.Menor {
-			err_cont++
-			error += fmt.Sprintf("- No puede se menor a %f", schema.Menor)
+			rules = append(rules, rule(CodeMin, map[string]interface{}{"min": schema.Menor}))
 		}
 	}
 	if schema.Mayor != 0 {
 		if value >= schema.Mayor {
-			err_cont++
-			error += fmt.Sprintf("- No puede se mayor a %f", schema.Mayor)
+			rules = append(rules, rule(CodeMax, map[string]interface{}{"max": schema.Mayor}))
 		}
 	}
 	if !schema.Negativo {
 		if value < float64(0) {
-			err_cont++
-			error += fmt.Sprintf("- no puede ser negativo")
+			rules = append(rules, rule(CodeNegative, nil))
 		}
 	}
 	if schema.Porcentaje {
 		value = value / float64(100)
 	}
-	if err_cont > 0 {
-		return 0, errors.New(error)
-	} else {
-		return value, nil
+	if len(rules) > 0 {
+		return nil, rules
 	}
+	return value, nil
 }
 
-func caseInt(value int64, schema TypeInt64) (int64, error) {
-	error := ""
-	err_cont := 0
+func caseInt(value int64, schema TypeInt64) (interface{}, []ValidationError) {
+	var rules []ValidationError
 	if !schema.Negativo {
 		if value < int64(0) {
-			err_cont++
-			error += fmt.Sprintf("- No puede ser negativo")
+			rules = append(rules, rule(CodeNegative, nil))
 		}
 	}
 	if schema.Min != 0 {
 		if value < schema.Min {
-			err_cont++
-			error += fmt.Sprintf("- No puede se menor a %d", schema.Min)
+			rules = append(rules, rule(CodeMin, map[string]interface{}{"min": schema.Min}))
 		}
 	}
 	if schema.Max != 0 {
 		if value > schema.Max {
-			err_cont++
-			error += fmt.Sprintf("- No puede se mayor a %d", schema.Max)
+			rules = append(rules, rule(CodeMax, map[string]interface{}{"max": schema.Max}))
 		}
 	}
-	if err_cont > 0 {
-		return int64(0), errors.New(error)
-	} else {
-		return value, nil
+	if len(rules) > 0 {
+		return nil, rules
 	}
+	return value, nil
 }
 
-func caseUint(value uint64, schema TypeUint64) (uint64, error) {
+func caseUint(value uint64, schema TypeUint64) (interface{}, []ValidationError) {
 	if schema.Max > 0 {
 		if value > schema.Max {
-			return 0, errors.New("- no esta en el rango permitido")
+			return nil, []ValidationError{rule(CodeOutOfRange, map[string]interface{}{"max": schema.Max})}
 		}
 	}
 	return value, nil
 }
 
+func caseDecimal(value Numeric, schema TypeDecimal) (interface{}, []ValidationError) {
+	if schema.Porcentaje {
+		value = Numeric{unscaled: value.int(), scale: value.scale + 2}
+	}
+	if schema.Scale > 0 || schema.Precision > 0 {
+		value = value.Round(schema.Scale, schema.Rounding)
+	}
+
+	var rules []ValidationError
+	if !schema.Negativo && value.Sign() < 0 {
+		rules = append(rules, rule(CodeNegative, nil))
+	}
+	if schema.Min != nil && value.Cmp(*schema.Min) < 0 {
+		rules = append(rules, rule(CodeMin, map[string]interface{}{"min": schema.Min.String()}))
+	}
+	if schema.Max != nil && value.Cmp(*schema.Max) > 0 {
+		rules = append(rules, rule(CodeMax, map[string]interface{}{"max": schema.Max.String()}))
+	}
+	if schema.Precision > 0 && value.integerDigits() > schema.Precision-int(schema.Scale) {
+		rules = append(rules, rule(CodePrecision, map[string]interface{}{"precision": schema.Precision, "scale": schema.Scale}))
+	}
+	if len(rules) > 0 {
+		return nil, rules
+	}
+	return value, nil
+}
+
+/** formatos aceptados por defecto en los campos Time */
+var defaultTimeLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006-01-02", "02/01/2006"}
+
+func caseTime(value interface{}, schema TypeTime) (interface{}, []ValidationError) {
+	loc := schema.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	var t time.Time
+	switch v := value.(type) {
+	case time.Time:
+		t = v
+	case string:
+		layouts := schema.Layouts
+		if len(layouts) == 0 {
+			layouts = defaultTimeLayouts
+		}
+		var err error
+		for _, layout := range layouts {
+			if t, err = time.ParseInLocation(layout, strings.TrimSpace(v), loc); err == nil {
+				break
+			}
+		}
+		if err != nil {
+			return nil, []ValidationError{rule(CodeDate, map[string]interface{}{"error": err.Error()})}
+		}
+	}
+	t = t.In(loc)
+
+	var rules []ValidationError
+	if !schema.Min.IsZero() && t.Before(schema.Min) {
+		rules = append(rules, rule(CodeMin, map[string]interface{}{"min": schema.Min.In(loc).Format(time.RFC3339)}))
+	}
+	if !schema.Max.IsZero() && t.After(schema.Max) {
+		rules = append(rules, rule(CodeMax, map[string]interface{}{"max": schema.Max.In(loc).Format(time.RFC3339)}))
+	}
+	if len(rules) > 0 {
+		return nil, rules
+	}
+	return t, nil
+}
+
+func caseBytes(value []byte, schema TypeBytes) (interface{}, []ValidationError) {
+	var rules []ValidationError
+	if schema.Max > 0 && len(value) > schema.Max {
+		rules = append(rules, rule(CodeMaxSize, map[string]interface{}{"max": schema.Max}))
+	}
+	if len(schema.ContentTypes) > 0 {
+		contentType := http.DetectContentType(value)
+		allowed := false
+		for _, v := range schema.ContentTypes {
+			if strings.HasPrefix(contentType, v) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			rules = append(rules, rule(CodeContentType, map[string]interface{}{"type": contentType, "allowed": strings.Join(schema.ContentTypes, ", ")}))
+		}
+	}
+	if len(rules) > 0 {
+		return nil, rules
+	}
+	return value, nil
+}
+
 func convertStringToType(types string, value_undefined interface{}) (val interface{}, err error) {
 	value := fmt.Sprintf("%v", value_undefined)
 	switch types {
@@ -812,6 +1257,9 @@ func convertStringToType(types string, value_undefined interface{}) (val interfa
 	case "float64":
 		val, err = strconv.ParseFloat(value, 64)
 		return
+	case "bool":
+		val, err = strconv.ParseBool(value)
+		return
 	default:
 		return nil, errors.New("No se puede convertir el tipo de dato")
 	}
@@ -882,6 +1330,68 @@ func strconvDataType(types string, values interface{}) (interface{}, error) {
 		}
 
 		return nil, errors.New("tipo de dato incorrecto")
+	case "decimal":
+		switch v := values.(type) {
+		case Numeric:
+			return v, nil
+		case *Numeric:
+			if v != nil {
+				return *v, nil
+			}
+		case string:
+			return ParseNumeric(v)
+		case []byte:
+			return ParseNumeric(string(v))
+		case int64:
+			return NewNumeric(v, 0), nil
+		case int:
+			return NewNumeric(int64(v), 0), nil
+		case uint64:
+			return ParseNumeric(strconv.FormatUint(v, 10))
+		case float64:
+			return ParseNumeric(strconv.FormatFloat(v, 'f', -1, 64))
+		}
+
+		return nil, errors.New("tipo de dato incorrecto")
+	case "bool":
+		switch v := values.(type) {
+		case bool:
+			return v, nil
+		case string:
+			return convertStringToType("bool", v)
+		case int64, uint64, float64:
+			if number := fmt.Sprint(v); number == "0" || number == "1" {
+				return number == "1", nil
+			}
+		}
+
+		return nil, errors.New("tipo de dato incorrecto")
+	case "time":
+		switch v := values.(type) {
+		case time.Time, string:
+			return v, nil
+		case *time.Time:
+			if v != nil {
+				return *v, nil
+			}
+		}
+
+		return nil, errors.New("tipo de dato incorrecto")
+	case "bytes":
+		switch v := values.(type) {
+		case []byte:
+			return v, nil
+		case string:
+			return []byte(v), nil
+		}
+
+		return nil, errors.New("tipo de dato incorrecto")
+	case "json":
+		raw, err := toJSON(values)
+		if err != nil {
+			return nil, errors.New("tipo de dato incorrecto")
+		}
+		return raw, nil
 	default:
 		return nil, errors.New("No se puede convertir el tipo de dato")
 	}
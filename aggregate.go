@@ -0,0 +1,188 @@
+package basicgorm
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+/*
+*
+Count retorna la cantidad de filas de la consulta ignorando ORDER BY y LIMIT.
+
+Ejemplo de uso:
+
+	queryBuilder := &Querys{Table: "requ_clientes"}
+	total, err := queryBuilder.Where("c_ubig", I, "120119").Count(QConfig{Database: "mi_database"})
+
+Parámetros:
+  - config: Configuración para la conexión a la base de datos.
+
+Devuelve:
+  - La cantidad de filas.
+  - Un error, si ocurre alguno durante la ejecución.
+*/
+func (q *Querys) Count(config QConfig) (int64, error) {
+	return q.CountContext(context.Background(), config)
+}
+
+/** CountContext igual que Count utilizando el contexto recibido */
+func (q *Querys) CountContext(ctx context.Context, config QConfig) (int64, error) {
+	var total int64
+	err := q.queryScalar(ctx, config, q.getCountQuery(), &total)
+	return total, err
+}
+
+/*
+*
+Sum retorna la suma de la columna sobre las filas de la consulta, si no existen filas retorna 0.
+
+Si la consulta tiene GROUP BY, HAVING, UNION o fue establecida con SetQueryString, la suma se calcula sobre
+el resultado de la consulta y la columna debe de ser una de las columnas del resultado.
+Para montos donde float64 pierde precisión utilizar SumNumeric.
+
+Ejemplo de uso:
+
+	queryBuilder := &Querys{Table: "stock_ventas"}
+	total, err := queryBuilder.Where("n_year", I, 2024).Sum(QConfig{Database: "mi_database"}, "s_tota")
+
+Parámetros:
+  - config: Configuración para la conexión a la base de datos.
+  - column: columna a sumar.
+
+Devuelve:
+  - La suma de la columna.
+  - Un error, si ocurre alguno durante la ejecución.
+*/
+func (q *Querys) Sum(config QConfig, column string) (float64, error) {
+	return q.SumContext(context.Background(), config, column)
+}
+
+/** SumContext igual que Sum utilizando el contexto recibido */
+func (q *Querys) SumContext(ctx context.Context, config QConfig, column string) (float64, error) {
+	return q.aggregateFloat(ctx, config, "sum", column)
+}
+
+/*
+*
+SumNumeric retorna la suma exacta de la columna (sin pasar por float64) sobre las filas de la consulta, si no existen filas retorna 0, ver Sum.
+
+Ejemplo de uso:
+
+	queryBuilder := &Querys{Table: "stock_ventas"}
+	total, err := queryBuilder.Where("n_year", I, 2024).SumNumeric(QConfig{Database: "mi_database"}, "s_tota")
+	fmt.Println(total.Round(2, RoundHalfEven))
+*/
+func (q *Querys) SumNumeric(config QConfig, column string) (Numeric, error) {
+	return q.SumNumericContext(context.Background(), config, column)
+}
+
+/** SumNumericContext igual que SumNumeric utilizando el contexto recibido */
+func (q *Querys) SumNumericContext(ctx context.Context, config QConfig, column string) (Numeric, error) {
+	return q.aggregateNumeric(ctx, config, "sum", column)
+}
+
+/** Avg retorna el promedio de la columna sobre las filas de la consulta, si no existen filas retorna 0, ver Sum */
+func (q *Querys) Avg(config QConfig, column string) (float64, error) {
+	return q.AvgContext(context.Background(), config, column)
+}
+
+/** AvgContext igual que Avg utilizando el contexto recibido */
+func (q *Querys) AvgContext(ctx context.Context, config QConfig, column string) (float64, error) {
+	return q.aggregateFloat(ctx, config, "avg", column)
+}
+
+/** AvgNumeric retorna el promedio exacto de la columna sobre las filas de la consulta, si no existen filas retorna 0, ver SumNumeric */
+func (q *Querys) AvgNumeric(config QConfig, column string) (Numeric, error) {
+	return q.AvgNumericContext(context.Background(), config, column)
+}
+
+/** AvgNumericContext igual que AvgNumeric utilizando el contexto recibido */
+func (q *Querys) AvgNumericContext(ctx context.Context, config QConfig, column string) (Numeric, error) {
+	return q.aggregateNumeric(ctx, config, "avg", column)
+}
+
+/** Min retorna el menor valor de la columna (numero, texto o fecha), nil si no existen filas, ver Sum */
+func (q *Querys) Min(config QConfig, column string) (interface{}, error) {
+	return q.MinContext(context.Background(), config, column)
+}
+
+/** MinContext igual que Min utilizando el contexto recibido */
+func (q *Querys) MinContext(ctx context.Context, config QConfig, column string) (interface{}, error) {
+	return q.aggregateValue(ctx, config, "min", column)
+}
+
+/** Max retorna el mayor valor de la columna (numero, texto o fecha), nil si no existen filas, ver Sum */
+func (q *Querys) Max(config QConfig, column string) (interface{}, error) {
+	return q.MaxContext(context.Background(), config, column)
+}
+
+/** MaxContext igual que Max utilizando el contexto recibido */
+func (q *Querys) MaxContext(ctx context.Context, config QConfig, column string) (interface{}, error) {
+	return q.aggregateValue(ctx, config, "max", column)
+}
+
+func (q *Querys) aggregateFloat(ctx context.Context, config QConfig, fn string, column string) (float64, error) {
+	query, err := q.getAggregateQuery(fn, column)
+	if err != nil {
+		return 0, err
+	}
+	var value sql.NullFloat64
+	if err := q.queryScalar(ctx, config, query, &value); err != nil {
+		return 0, err
+	}
+	return value.Float64, nil
+}
+
+/** ejecuta el agregado y lo lee como Numeric, NULL (sin filas) retorna 0 */
+func (q *Querys) aggregateNumeric(ctx context.Context, config QConfig, fn string, column string) (Numeric, error) {
+	query, err := q.getAggregateQuery(fn, column)
+	if err != nil {
+		return Numeric{}, err
+	}
+	var value *Numeric
+	if err := q.queryScalar(ctx, config, query, &value); err != nil || value == nil {
+		return Numeric{}, err
+	}
+	return *value, nil
+}
+
+func (q *Querys) aggregateValue(ctx context.Context, config QConfig, fn string, column string) (interface{}, error) {
+	query, err := q.getAggregateQuery(fn, column)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	if err := q.queryScalar(ctx, config, query, &value); err != nil {
+		return nil, err
+	}
+	if b, ok := value.([]byte); ok {
+		value = string(b)
+	}
+	return value, nil
+}
+
+/*
+getAggregateQuery retorna la consulta que aplica la función de agregado sobre la columna, reemplazando las columnas del SELECT,
+si la consulta tiene GROUP BY, HAVING, UNION o fue establecida con SetQueryString el agregado se aplica sobre su resultado.
+*/
+func (q *Querys) getAggregateQuery(fn string, column string) (string, error) {
+	column, err := q.ident(column)
+	if err != nil {
+		return "", err
+	}
+	if q.query.workQueryFull {
+		return fmt.Sprintf("SELECT %s(%s) FROM (%s) AS basicgorm_aggregate", fn, column, q.query.queryFull), nil
+	}
+	if q.query.Select == "" {
+		q.Select()
+	}
+	if q.query.GroupBy != "" || q.query.Having != "" || len(q.query.Compound) > 0 {
+		return fmt.Sprintf("%sSELECT %s(%s) FROM (%s) AS basicgorm_aggregate", q.getWith(), fn, column, q.getCompoundBody()), nil
+	}
+	queryString := fmt.Sprintf("%sSELECT %s(%s) FROM %s", q.getWith(), fn, column, q.query.from)
+	for _, v := range q.query.Join {
+		queryString += v
+	}
+	return queryString + q.query.Where, nil
+}
@@ -0,0 +1,299 @@
+package basicgorm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+/** códigos de los mensajes de ejecución de SqlExecSingle, SqlExecMultiple y BulkCopy */
+const (
+	CodeSQLPrepare   = "sql_prepare"    //Error al preparar la sentencia
+	CodeSQLExec      = "sql_exec"       //Error al ejecutar la sentencia, params: action
+	CodeSQLTx        = "sql_tx"         //Error al iniciar la transacción
+	CodeSQLCommit    = "sql_commit"     //Error al confirmar la transacción
+	CodeSQLPing      = "sql_ping"       //Error al verificar la conexión
+	CodeSQLCopy      = "sql_copy"       //Error al enviar los datos con COPY
+	CodeNoInsertData = "no_insert_data" //No existen datos para insertar
+	CodeNoUpdateData = "no_update_data" //No existen datos para actualizar o eliminar
+)
+
+/*
+Catalog genera los mensajes de los errores a partir de su código (ver las constantes Code*),
+params contiene los valores de la regla incumplida y en los errores de validación la descripción del campo en "field".
+
+Se puede implementar para agregar otros idiomas y registrarlo con RegisterCatalog.
+*/
+type Catalog interface {
+	Message(code string, params map[string]interface{}) string
+}
+
+/*
+MessageBundle catalogo de mensajes código => plantilla, las plantillas reemplazan {nombre} por el valor del parámetro.
+Los códigos sin plantilla utilizan la plantilla "default".
+
+Ejemplo de uso:
+
+	basicgorm.RegisterCatalog("pt", basicgorm.MessageBundle{
+		basicgorm.CodeRequired: "O campo {field} é obrigatório",
+		"default":              "O campo {field} não é válido",
+	})
+*/
+type MessageBundle map[string]string
+
+func (b MessageBundle) Message(code string, params map[string]interface{}) string {
+	template, ok := b[code]
+	if !ok {
+		if template, ok = b["default"]; !ok {
+			return code
+		}
+	}
+	for key, value := range params {
+		template = strings.ReplaceAll(template, "{"+key+"}", fmt.Sprint(value))
+	}
+	return template
+}
+
+/** mensajes en español, idioma por defecto */
+var bundleES = MessageBundle{
+	CodeRequired:      "El campo {field} es Requerido",
+	CodeEmpty:         "El campo {field} no puede estar vació",
+	CodeNotUpdatable:  "El campo {field} no puede ser modificado",
+	CodeNotFilterable: "El campo {field} no puede ser utilizado de esta forma",
+	CodeInvalidType:   "El campo {field} tiene un tipo de dato incorrecto",
+	CodeUnsupported:   "El campo {field} tiene un tipo de dato no asignado",
+	CodeRegex:         "El campo {field} no cumple con las características",
+	CodeDate:          "El campo {field} no es una fecha valida: {error}",
+	CodeMinLength:     "El campo {field} no cumple los caracteres mínimos que debe tener ({min})",
+	CodeMaxLength:     "El campo {field} no cumple los caracteres máximos que debe tener ({max})",
+	CodeMin:           "El campo {field} no puede ser menor a {min}",
+	CodeMax:           "El campo {field} no puede ser mayor a {max}",
+	CodeNegative:      "El campo {field} no puede ser negativo",
+	CodeOutOfRange:    "El campo {field} no esta en el rango permitido ({max})",
+	CodeMaxSize:       "El campo {field} supera el tamaño máximo permitido ({max} bytes)",
+	CodeContentType:   "El campo {field} tiene un tipo de contenido no permitido ({type}), se permite: {allowed}",
+	CodePrecision:     "El campo {field} supera la precisión permitida ({precision}, {scale})",
+	CodeMaxDepth:      "El campo {field} supera la cantidad de niveles permitidos ({max})",
+	CodeJSONRequired:  "El campo {field} no tiene la llave obligatoria {path}",
+	CodeJSONType:      "El campo {field} debe de tener un valor {type} en {path}",
+	CodeSQLPrepare:    "error sql prepare",
+	CodeSQLExec:       "error sql {action}",
+	CodeSQLTx:         "error sql tx",
+	CodeSQLCommit:     "error sql commit",
+	CodeSQLPing:       "error sql ping",
+	CodeSQLCopy:       "error sql COPY",
+	CodeNoInsertData:  "no existen datos para insertar",
+	CodeNoUpdateData:  "no existen datos para actualizar",
+	"default":         "El campo {field} no es valido",
+}
+
+/** mensajes en ingles */
+var bundleEN = MessageBundle{
+	CodeRequired:      "The field {field} is required",
+	CodeEmpty:         "The field {field} cannot be empty",
+	CodeNotUpdatable:  "The field {field} cannot be modified",
+	CodeNotFilterable: "The field {field} cannot be used as a filter",
+	CodeInvalidType:   "The field {field} has an invalid data type",
+	CodeUnsupported:   "The field {field} has an unsupported data type",
+	CodeRegex:         "The field {field} does not match the required format",
+	CodeDate:          "The field {field} is not a valid date",
+	CodeMinLength:     "The field {field} must have at least {min} characters",
+	CodeMaxLength:     "The field {field} must have at most {max} characters",
+	CodeMin:           "The field {field} cannot be less than {min}",
+	CodeMax:           "The field {field} cannot be greater than {max}",
+	CodeNegative:      "The field {field} cannot be negative",
+	CodeOutOfRange:    "The field {field} is out of the allowed range ({max})",
+	CodeMaxSize:       "The field {field} exceeds the maximum allowed size ({max} bytes)",
+	CodeContentType:   "The field {field} has a content type that is not allowed ({type}), allowed: {allowed}",
+	CodePrecision:     "The field {field} exceeds the allowed precision ({precision}, {scale})",
+	CodeMaxDepth:      "The field {field} exceeds the allowed nesting depth ({max})",
+	CodeJSONRequired:  "The field {field} is missing the required key {path}",
+	CodeJSONType:      "The field {field} must have a {type} value at {path}",
+	CodeSQLPrepare:    "sql prepare error",
+	CodeSQLExec:       "sql {action} error",
+	CodeSQLTx:         "sql transaction error",
+	CodeSQLCommit:     "sql commit error",
+	CodeSQLPing:       "sql ping error",
+	CodeSQLCopy:       "sql COPY error",
+	CodeNoInsertData:  "there is no data to insert",
+	CodeNoUpdateData:  "there is no data to update",
+	"default":         "The field {field} is not valid",
+}
+
+/** registro de catálogos por idioma */
+var (
+	catalogMutex   sync.RWMutex
+	catalogs       = map[string]Catalog{"es": bundleES, "en": bundleEN}
+	defaultCatalog = "es"
+)
+
+/** llave del idioma en el contexto */
+type catalogKey struct{}
+
+/*
+RegisterCatalog registra o reemplaza el catalogo de mensajes de un idioma.
+
+Parámetros:
+  - lang: código del idioma (por ejemplo "pt").
+  - catalog: catalogo de mensajes.
+*/
+func RegisterCatalog(lang string, catalog Catalog) {
+	catalogMutex.Lock()
+	defer catalogMutex.Unlock()
+	catalogs[lang] = catalog
+}
+
+/*
+SetCatalog establece el idioma por defecto de los mensajes, inicialmente "es".
+
+Parámetros:
+  - lang: código de un idioma registrado ("es", "en" o uno registrado con RegisterCatalog).
+
+Devuelve:
+  - Un error, si el idioma no esta registrado.
+*/
+func SetCatalog(lang string) error {
+	catalogMutex.Lock()
+	defer catalogMutex.Unlock()
+	if _, ok := catalogs[lang]; !ok {
+		return fmt.Errorf("catalogo de mensajes %s no registrado", lang)
+	}
+	defaultCatalog = lang
+	return nil
+}
+
+/** GetCatalog retorna el catalogo del idioma, si no esta registrado retorna el catalogo por defecto */
+func GetCatalog(lang string) Catalog {
+	catalogMutex.RLock()
+	defer catalogMutex.RUnlock()
+	if catalog, ok := catalogs[lang]; ok {
+		return catalog
+	}
+	return catalogs[defaultCatalog]
+}
+
+/*
+WithCatalog retorna un contexto que utiliza el idioma recibido en los mensajes de ejecución de los métodos *Context
+(por ejemplo ExecContext o BulkCopyContext), permite seleccionar el idioma por petición.
+
+Ejemplo de uso:
+
+	ctx := basicgorm.WithCatalog(r.Context(), r.Header.Get("Accept-Language"))
+	err := crud.ExecContext(ctx, "mi_database")
+
+Los errores de validación se generan sin contexto, se traducen al idioma del contexto con TranslateContext.
+*/
+func WithCatalog(ctx context.Context, lang string) context.Context {
+	return context.WithValue(ctx, catalogKey{}, lang)
+}
+
+/** CatalogFromContext retorna el catalogo del idioma del contexto, o el catalogo por defecto */
+func CatalogFromContext(ctx context.Context) Catalog {
+	lang, _ := ctx.Value(catalogKey{}).(string)
+	return GetCatalog(lang)
+}
+
+/*
+Translate genera nuevamente los mensajes de los errores de validación (ValidationErrors, también dentro de BulkErrors)
+y de ejecución (ExecError) con el catalogo recibido, los demás errores se retornan sin cambios.
+
+Ejemplo de uso:
+
+	err := crud.New(schema, data).Insert()
+	err = basicgorm.Translate(err, basicgorm.GetCatalog("en"))
+*/
+func Translate(err error, catalog Catalog) error {
+	var bulk BulkErrors
+	if errors.As(err, &bulk) {
+		translated := make(BulkErrors, len(bulk))
+		for i, v := range bulk {
+			translated[i] = BulkError{Index: v.Index, Err: Translate(v.Err, catalog)}
+		}
+		return translated
+	}
+	var errs ValidationErrors
+	if errors.As(err, &errs) {
+		return errs.Translate(catalog)
+	}
+	var execErr ExecError
+	if errors.As(err, &execErr) {
+		return execErr.Translate(catalog)
+	}
+	return err
+}
+
+/*
+TranslateContext igual que Translate utilizando el idioma del contexto (ver WithCatalog), permite traducir por petición
+los errores de validación retornados por Insert, Update, Delete y Upsert, que se generan sin contexto.
+
+Ejemplo de uso:
+
+	err := crud.New(schema, data).Insert()
+	err = basicgorm.TranslateContext(r.Context(), err)
+*/
+func TranslateContext(ctx context.Context, err error) error {
+	return Translate(err, CatalogFromContext(ctx))
+}
+
+/** Translate retorna una copia de los errores con los mensajes generados por el catalogo recibido */
+func (e ValidationErrors) Translate(catalog Catalog) ValidationErrors {
+	translated := make(ValidationErrors, len(e))
+	for i, v := range e {
+		v.Message = fieldMessage(catalog, v.Description, v.Code, v.Params)
+		translated[i] = v
+	}
+	return translated
+}
+
+/** genera el mensaje de un error de validación agregando la descripción del campo a los parámetros */
+func fieldMessage(catalog Catalog, description string, code string, params map[string]interface{}) string {
+	values := make(map[string]interface{}, len(params)+1)
+	for k, v := range params {
+		values[k] = v
+	}
+	values["field"] = description
+	return catalog.Message(code, values)
+}
+
+/*
+ExecError error de ejecución de SqlExecSingle, SqlExecMultiple y BulkCopy.
+
+Code es uno de los códigos de ejecución (CodeSQLExec, CodeNoInsertData, ...), Params sus valores y Message el texto
+generado por el catalogo del contexto, o el idioma por defecto si se genero sin contexto (ver Translate).
+Err contiene el error original de la base de datos, nil cuando el error no proviene de la ejecución (por ejemplo sin datos para insertar).
+*/
+type ExecError struct {
+	Code    string                 `json:"code"`             //Código del error
+	Params  map[string]interface{} `json:"params,omitempty"` //Valores del mensaje
+	Message string                 `json:"message"`          //Mensaje del error
+	Err     error                  `json:"-"`                //Error original
+}
+
+func (e ExecError) Error() string {
+	if e.Err == nil {
+		return e.Message
+	}
+	return e.Message + ": " + e.Err.Error()
+}
+
+func (e ExecError) Unwrap() error {
+	return e.Err
+}
+
+/** Translate retorna una copia del error con el mensaje generado por el catalogo recibido */
+func (e ExecError) Translate(catalog Catalog) ExecError {
+	e.Message = catalog.Message(e.Code, e.Params)
+	return e
+}
+
+/** envuelve el error de ejecución con el mensaje del código en el idioma del contexto */
+func execError(ctx context.Context, code string, params map[string]interface{}, err error) error {
+	return ExecError{Code: code, Params: params, Message: CatalogFromContext(ctx).Message(code, params), Err: contextError(ctx, err)}
+}
+
+/** retorna el error del código sin error original, con el mensaje en el idioma del contexto */
+func catalogError(ctx context.Context, code string) error {
+	return execError(ctx, code, nil, nil)
+}
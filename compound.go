@@ -0,0 +1,122 @@
+package basicgorm
+
+import (
+	"errors"
+	"fmt"
+)
+
+/** operaciones para combinar los resultados de varias consultas */
+type TypeCompound string
+
+const (
+	UNION     TypeCompound = "UNION"
+	UNION_ALL TypeCompound = "UNION ALL"
+	INTERSECT TypeCompound = "INTERSECT"
+	EXCEPT    TypeCompound = "EXCEPT"
+)
+
+/** consulta combinada con la consulta principal */
+type compound struct {
+	tp TypeCompound
+	q  *Querys
+}
+
+/*
+*
+Union combina los resultados de la consulta con los de las consultas recibidas eliminando las filas duplicadas.
+
+Cada consulta se agrega entre paréntesis y sus argumentos se agregan a continuación de los de la consulta principal
+renumerando sus placeholders ($n), las consultas se leen al generar la consulta por lo que se pueden seguir modificando.
+Las cláusulas ORDER BY y LIMIT de la consulta principal se aplican al resultado combinado.
+
+Ejemplo de uso:
+
+	compras := &Querys{Table: "stock_compras"}
+	compras.Select("c_prod", "s_cant", "0 AS action").Where("n_year", I, 2024)
+
+	ventas := &Querys{Table: "stock_ventas"}
+	ventas.Select("c_prod", "s_cant", "1 AS action").Where("n_year", I, 2024)
+
+	compras.UnionAll(ventas).OrderBy("c_prod").Limit(10)
+	// (SELECT c_prod,s_cant,0 AS action FROM stock_compras WHERE n_year = $1) UNION ALL (SELECT c_prod,s_cant,1 AS action FROM stock_ventas WHERE n_year = $2) ORDER BY c_prod LIMIT 10
+
+Parámetros:
+  - others: consultas a combinar.
+
+Devuelve:
+  - Un puntero al struct Querys actualizado para permitir el encadenamiento de métodos.
+*/
+func (q *Querys) Union(others ...*Querys) *Querys {
+	return q.combine(UNION, others)
+}
+
+/** UnionAll combina los resultados de las consultas conservando las filas duplicadas, ver Union */
+func (q *Querys) UnionAll(others ...*Querys) *Querys {
+	return q.combine(UNION_ALL, others)
+}
+
+/** Intersect retorna solo las filas que existen en el resultado de todas las consultas, ver Union */
+func (q *Querys) Intersect(others ...*Querys) *Querys {
+	return q.combine(INTERSECT, others)
+}
+
+/** Except retorna las filas de la consulta que no existen en el resultado de las consultas recibidas, ver Union */
+func (q *Querys) Except(others ...*Querys) *Querys {
+	return q.combine(EXCEPT, others)
+}
+
+func (q *Querys) combine(tp TypeCompound, others []*Querys) *Querys {
+	if q.query.workQueryFull {
+		return q.setError(errors.New("no se puede combinar una consulta establecida con SetQueryString, utilice la consulta como parámetro"))
+	}
+	for _, other := range others {
+		if other == nil {
+			return q.setError(fmt.Errorf("consulta vacía para %s", tp))
+		}
+		if other == q || other.combines(q) {
+			return q.setError(fmt.Errorf("una consulta no puede combinarse consigo misma en %s", tp))
+		}
+		if other.err != nil {
+			return q.setError(other.err)
+		}
+		q.query.Compound = append(q.query.Compound, compound{tp: tp, q: other})
+	}
+	return q
+}
+
+/** indica si la consulta q ya forma parte de las consultas combinadas, evita referencias circulares */
+func (q *Querys) combines(target *Querys) bool {
+	for _, c := range q.query.Compound {
+		if c.q == target || c.q.combines(target) {
+			return true
+		}
+	}
+	return false
+}
+
+/** retorna la consulta sin ORDER BY ni LIMIT incluyendo las consultas combinadas */
+func (q *Querys) getCompoundBody() string {
+	body := q.getQueryBody()
+	if len(q.query.Compound) == 0 {
+		return body
+	}
+	body = fmt.Sprintf("(%s)", body)
+	offset := len(q.args)
+	for _, c := range q.query.Compound {
+		body += fmt.Sprintf(" %s (%s)", c.tp, shiftPlaceholders(c.q.GetQuery(), offset))
+		offset += len(c.q.getArgs())
+	}
+	return body
+}
+
+/** retorna los argumentos de la consulta seguidos de los argumentos de las consultas combinadas */
+func (q *Querys) getArgs() []interface{} {
+	if len(q.query.Compound) == 0 {
+		return q.args
+	}
+	args := append([]interface{}{}, q.args...)
+	for _, c := range q.query.Compound {
+		args = append(args, c.q.getArgs()...)
+	}
+	return args
+}
@@ -0,0 +1,210 @@
+package basicgorm
+
+import (
+	"fmt"
+)
+
+/*
+Cond construye un grupo de condiciones que se agregara entre paréntesis a la cláusula WHERE,
+los argumentos se numeran en la misma secuencia ($n) que el resto de la consulta.
+
+Se obtiene mediante Querys.WhereGroup, Querys.AndGroup, Querys.OrGroup o Querys.Not.
+*/
+type Cond struct {
+	q    *Querys
+	expr string
+	err  error
+}
+
+/*
+*
+WhereGroup establece la cláusula WHERE con un grupo de condiciones entre paréntesis.
+
+Ejemplo de uso:
+
+	queryBuilder := &Querys{Table: "mi_tabla"}
+	queryBuilder.Select().WhereGroup(func(g *Cond) {
+		g.Where("campo1", I, 1).Or("campo2", I, 2)
+	}).AndGroup(func(g *Cond) {
+		g.Where("campo3", MY, 3).OrGroup(func(g *Cond) {
+			g.Where("campo4", LIKE, "%a%").And("campo5", IN, []interface{}{"a", "b"})
+		})
+	})
+	consultaFinal := queryBuilder.GetQuery()
+	// SELECT * FROM mi_tabla WHERE (campo1 = $1 OR campo2 = $2) AND (campo3 > $3 OR (campo4 LIKE $4 AND campo5 IN ($5,$6)))
+
+Parámetros:
+  - fn: función que recibe el grupo donde se agregan las condiciones.
+
+Devuelve:
+  - Un puntero al struct Querys actualizado para permitir el encadenamiento de métodos.
+*/
+func (q *Querys) WhereGroup(fn func(g *Cond)) *Querys {
+	q.resetWhere()
+	q.query.Where = ""
+	expr, ok := q.group(fn)
+	if ok {
+		q.query.Where = fmt.Sprintf(" WHERE (%s)", expr)
+	}
+	return q
+}
+
+/*
+*
+AndGroup añade a la cláusula WHERE existente un grupo de condiciones entre paréntesis unido con AND.
+Si la cláusula WHERE aún no está especificada en la consulta, esta función no hace nada.
+
+Parámetros:
+  - fn: función que recibe el grupo donde se agregan las condiciones.
+
+Devuelve:
+  - Un puntero al struct Querys actualizado para permitir el encadenamiento de métodos.
+*/
+func (q *Querys) AndGroup(fn func(g *Cond)) *Querys {
+	if q.query.Where == "" {
+		return q
+	}
+	if expr, ok := q.group(fn); ok {
+		q.query.Where += fmt.Sprintf(" AND (%s)", expr)
+	}
+	return q
+}
+
+/*
+*
+OrGroup añade a la cláusula WHERE existente un grupo de condiciones entre paréntesis unido con OR.
+Si la cláusula WHERE aún no está especificada en la consulta, esta función no hace nada.
+
+Parámetros:
+  - fn: función que recibe el grupo donde se agregan las condiciones.
+
+Devuelve:
+  - Un puntero al struct Querys actualizado para permitir el encadenamiento de métodos.
+*/
+func (q *Querys) OrGroup(fn func(g *Cond)) *Querys {
+	if q.query.Where == "" {
+		return q
+	}
+	if expr, ok := q.group(fn); ok {
+		q.query.Where += fmt.Sprintf(" OR (%s)", expr)
+	}
+	return q
+}
+
+/*
+*
+Not niega un grupo de condiciones, si la cláusula WHERE no existe la establece (WHERE NOT (...)),
+en caso contrario lo añade con AND (AND NOT (...)).
+
+Parámetros:
+  - fn: función que recibe el grupo donde se agregan las condiciones.
+
+Devuelve:
+  - Un puntero al struct Querys actualizado para permitir el encadenamiento de métodos.
+*/
+func (q *Querys) Not(fn func(g *Cond)) *Querys {
+	if q.query.Where == "" {
+		q.resetWhere()
+		if expr, ok := q.group(fn); ok {
+			q.query.Where = fmt.Sprintf(" WHERE NOT (%s)", expr)
+		}
+		return q
+	}
+	if expr, ok := q.group(fn); ok {
+		q.query.Where += fmt.Sprintf(" AND NOT (%s)", expr)
+	}
+	return q
+}
+
+/** construye el grupo, retorna false si el grupo quedo vació o tuvo errores */
+func (q *Querys) group(fn func(g *Cond)) (string, bool) {
+	args, argsLen := len(q.args), q.argsLen
+	g := &Cond{q: q}
+	fn(g)
+	if g.err != nil {
+		/** descarta los argumentos que el grupo alcanzo a agregar */
+		q.args, q.argsLen = q.args[:args], argsLen
+		q.setError(g.err)
+		return "", false
+	}
+	return g.expr, g.expr != ""
+}
+
+/*
+Where agrega la primera condición del grupo, si el grupo ya tiene condiciones se une con AND.
+
+Parámetros:
+  - column: campo o expresión a comparar.
+  - op: operador de la condición.
+  - arg: valor que se compara en la condición.
+*/
+func (c *Cond) Where(column string, op OperatorWhere, arg interface{}) *Cond {
+	return c.And(column, op, arg)
+}
+
+/** And agrega una condición al grupo unida con AND */
+func (c *Cond) And(column string, op OperatorWhere, arg interface{}) *Cond {
+	return c.add("AND", column, op, arg)
+}
+
+/** Or agrega una condición al grupo unida con OR */
+func (c *Cond) Or(column string, op OperatorWhere, arg interface{}) *Cond {
+	return c.add("OR", column, op, arg)
+}
+
+/** AndGroup agrega un sub grupo de condiciones entre paréntesis unido con AND */
+func (c *Cond) AndGroup(fn func(g *Cond)) *Cond {
+	return c.addGroup("AND", "", fn)
+}
+
+/** OrGroup agrega un sub grupo de condiciones entre paréntesis unido con OR */
+func (c *Cond) OrGroup(fn func(g *Cond)) *Cond {
+	return c.addGroup("OR", "", fn)
+}
+
+/** Not agrega un sub grupo de condiciones negado (NOT (...)) unido con AND */
+func (c *Cond) Not(fn func(g *Cond)) *Cond {
+	return c.addGroup("AND", "NOT ", fn)
+}
+
+/** OrNot agrega un sub grupo de condiciones negado (NOT (...)) unido con OR */
+func (c *Cond) OrNot(fn func(g *Cond)) *Cond {
+	return c.addGroup("OR", "NOT ", fn)
+}
+
+func (c *Cond) add(logic string, column string, op OperatorWhere, arg interface{}) *Cond {
+	if c.err != nil {
+		return c
+	}
+	condition, err := getCondition(c.q, column, op, arg)
+	if err != nil {
+		c.err = err
+		return c
+	}
+	c.append(logic, condition)
+	return c
+}
+
+func (c *Cond) addGroup(logic string, prefix string, fn func(g *Cond)) *Cond {
+	if c.err != nil {
+		return c
+	}
+	g := &Cond{q: c.q}
+	fn(g)
+	if g.err != nil {
+		c.err = g.err
+		return c
+	}
+	if g.expr != "" {
+		c.append(logic, fmt.Sprintf("%s(%s)", prefix, g.expr))
+	}
+	return c
+}
+
+func (c *Cond) append(logic string, condition string) {
+	if c.expr == "" {
+		c.expr = condition
+	} else {
+		c.expr += fmt.Sprintf(" %s %s", logic, condition)
+	}
+}
@@ -1,73 +1,58 @@
 package basicgorm
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
-	"os"
 
-	"github.com/joho/godotenv"
 	_ "github.com/lib/pq"
 )
 
 func Connection(database string) (*sql.DB, error) {
-	err := godotenv.Load()
+	config, err := getDefaultConfig()
 	if err != nil {
 		fmt.Println(err)
 		return nil, errors.New("error al obtener configuración del servidor")
 	}
-	server := os.Getenv("ENV_DDBB_SERVER")
-	user := os.Getenv("ENV_DDBB_USER")
-	password := os.Getenv("ENV_DDBB_PASSWORD")
-	port := os.Getenv("ENV_DDBB_PORT")
-	ssl := os.Getenv("ENV_DDBB_SSL")
-	sslmode := "disable"
-	if ssl == "true" {
-		sslmode = "require"
-	}
-	connection_string := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s", server, port, user, password, database, sslmode)
-	db, err := sql.Open("postgres", connection_string)
-	if err != nil {
-		errs := fmt.Errorf("error connection: %s ", err.Error())
-		return nil, errs
-	}
-	err = db.Ping()
-	if err != nil {
-		db.Close()
-		errs := fmt.Errorf("error creating connection: %s", err.Error())
-		return nil, errs
-	}
-	return db, nil
+	config.DBName = database
+	return ConnectionConfig(config)
 }
 
 func ConnectionCloud() (*sql.DB, error) {
-	err := godotenv.Load()
+	config, err := getDefaultConfig()
 	if err != nil {
 		fmt.Println(err)
 		return nil, errors.New("error al obtener configuración del servidor cloud")
 	}
-	server := os.Getenv("ENV_DDBB_SERVER")
-	user := os.Getenv("ENV_DDBB_USER")
-	password := os.Getenv("ENV_DDBB_PASSWORD")
-	database := os.Getenv("ENV_DDBB_DATABASE")
-	port := os.Getenv("ENV_DDBB_PORT")
-	ssl := os.Getenv("ENV_DDBB_SSL")
-	sslmode := "disable"
-	if ssl == "true" {
-		sslmode = "require"
-	}
+	return ConnectionConfig(config)
+}
 
-	connection_string := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s", server, port, user, password, database, sslmode)
+/*
+ConnectionConfig abre una conexión con los datos de Config y verifica que el servidor responda.
+
+Parámetros:
+  - config: datos de conexión al servidor.
+
+Devuelve:
+  - La conexión a la base de datos.
+  - Un error, si no se pudo establecer la conexión.
+*/
+func ConnectionConfig(config Config) (*sql.DB, error) {
+	return ConnectionConfigContext(context.Background(), config)
+}
 
-	db, err := sql.Open("postgres", connection_string)
+/** ConnectionConfigContext igual que ConnectionConfig, la verificación del servidor respeta la cancelación y el tiempo limite del contexto */
+func ConnectionConfigContext(ctx context.Context, config Config) (*sql.DB, error) {
+	db, err := sql.Open("postgres", config.DSN())
 	if err != nil {
 		errs := fmt.Errorf("error connection: %s ", err.Error())
 		return nil, errs
 	}
-	err = db.Ping()
+	err = db.PingContext(ctx)
 	if err != nil {
 		db.Close()
-		errs := fmt.Errorf("error creating connection: %s", err.Error())
+		errs := fmt.Errorf("error creating connection: %w", contextError(ctx, err))
 		return nil, errs
 	}
 	return db, nil
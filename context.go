@@ -0,0 +1,58 @@
+package basicgorm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+/*
+ErrQueryCanceled se retorna (envuelto junto al error original) cuando una consulta fue cancelada
+por el contexto, por superar su tiempo limite o por el servidor (statement_timeout).
+
+Ejemplo de uso:
+
+	err := crud.ExecContext(ctx, "mi_database")
+	if errors.Is(err, basicgorm.ErrQueryCanceled) {
+		// la petición fue cancelada
+	}
+*/
+var ErrQueryCanceled = errors.New("consulta cancelada")
+
+/** código de error de PostgreSQL query_canceled, incluye la cancelación por statement_timeout */
+const pqQueryCanceled = "57014"
+
+/*
+contextError identifica si el error ocurrió por la cancelación de la consulta y en ese caso
+lo envuelve con ErrQueryCanceled, los demás errores se retornan sin cambios.
+*/
+func contextError(ctx context.Context, err error) error {
+	if err != nil && ctx.Err() != nil && !errors.Is(err, ErrQueryCanceled) {
+		return fmt.Errorf("%w: %w", ErrQueryCanceled, err)
+	}
+	return canceledError(err)
+}
+
+/** igual que contextError cuando ya no se cuenta con el contexto, por ejemplo al leer las filas del resultado */
+func canceledError(err error) error {
+	if err == nil || errors.Is(err, ErrQueryCanceled) {
+		return err
+	}
+	var pqErr *pq.Error
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) ||
+		(errors.As(err, &pqErr) && pqErr.Code == pqQueryCanceled) {
+		return fmt.Errorf("%w: %w", ErrQueryCanceled, err)
+	}
+	return err
+}
+
+/** aplica el tiempo limite de la consulta al contexto, si timeout es cero el contexto no se modifica */
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
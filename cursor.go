@@ -0,0 +1,286 @@
+package basicgorm
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+)
+
+/** contador utilizado para generar nombres únicos de cursores del servidor */
+var cursorSeq uint64
+
+/*
+Cursor recorre las filas del resultado de una consulta una por una sin cargarlas todas en memoria.
+
+Se obtiene con Querys.Rows luego de Exec o ExecTx, el resultado se cierra al terminar de recorrer las filas,
+al ocurrir un error o al llamar a Close; se debe de llamar a Close (por ejemplo con defer) si se deja de recorrer antes de terminar.
+
+Ejemplo de uso:
+
+	rows := queryBuilder.Select().Exec(QConfig{Database: "mi_database"}).Rows()
+	defer rows.Close()
+	for rows.Next() {
+		var sucursal Sucursal
+		if err := rows.Scan(&sucursal); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+*/
+type Cursor struct {
+	q      *Querys
+	err    error
+	closed bool
+	fields map[reflect.Type]map[string][]int /** relación columna => campo por tipo de struct leído con Scan */
+}
+
+/*
+Rows retorna un cursor para recorrer las filas del resultado de la consulta ejecutada con Exec o ExecTx.
+
+Devuelve:
+  - Un puntero a Cursor, si la consulta tuvo errores el cursor no tiene filas y Err retorna el error.
+*/
+func (q *Querys) Rows() *Cursor {
+	c := &Cursor{q: q, err: q.err}
+	if q.err != nil || q.rowSql == nil {
+		q.closeRows()
+		c.closed = true
+		if c.err == nil {
+			c.err = errors.New("la consulta no fue ejecutada")
+		}
+	}
+	return c
+}
+
+/*
+Each recorre las filas del resultado de la consulta ejecutada con Exec o ExecTx llamando a fn por cada fila,
+las filas se leen una por una sin cargarlas todas en memoria.
+
+Ejemplo de uso:
+
+	err := queryBuilder.Select().Exec(QConfig{Database: "mi_database"}).Each(func(row map[string]interface{}) error {
+		return writer.Write(row)
+	})
+
+Parámetros:
+  - fn: función que recibe cada fila como mapa columna => valor, si retorna un error se detiene el recorrido.
+
+Devuelve:
+  - El error retornado por fn o el ocurrido durante la lectura, el resultado se cierra en todos los casos.
+*/
+func (q *Querys) Each(fn func(row map[string]interface{}) error) error {
+	rows := q.Rows()
+	defer rows.Close()
+	for rows.Next() {
+		row, err := rows.Map()
+		if err != nil {
+			return err
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+/** Next avanza a la siguiente fila, retorna false al terminar o al ocurrir un error (ver Err) y cierra el resultado */
+func (c *Cursor) Next() bool {
+	if c.closed {
+		return false
+	}
+	if c.q.rowSql.Next() {
+		return true
+	}
+	c.err = canceledError(c.q.rowSql.Err())
+	c.Close()
+	return false
+}
+
+/** Columns retorna los nombres de las columnas del resultado */
+func (c *Cursor) Columns() []string {
+	return c.q.colSql
+}
+
+/** Map retorna la fila actual como mapa columna => valor */
+func (c *Cursor) Map() (map[string]interface{}, error) {
+	row, err := scanMap(c.q.rowSql, c.q.colSql, c.q.decoders)
+	return row, c.setErr(err)
+}
+
+/*
+Scan lee la fila actual en dest.
+
+Parámetros:
+  - dest: puntero a struct (las columnas se relacionan mediante la etiqueta db, igual que en Querys.Scan)
+    o puntero a un tipo simple, en cuyo caso se lee la primera columna.
+
+Devuelve:
+  - Un error, si ocurre alguno durante la lectura de la fila.
+*/
+func (c *Cursor) Scan(dest interface{}) error {
+	if c.closed {
+		return c.setErr(errors.New("el cursor se encuentra cerrado"))
+	}
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return c.setErr(errors.New("se esperaba un puntero"))
+	}
+	if isScalarType(v.Elem().Type()) {
+		return c.setErr(scanFirst(c.q.rowSql, len(c.q.colSql), dest))
+	}
+	target := v.Elem()
+	if target.Kind() == reflect.Ptr {
+		target.Set(reflect.New(target.Type().Elem()))
+		target = target.Elem()
+	}
+	t := target.Type()
+	if c.fields == nil {
+		c.fields = make(map[reflect.Type]map[string][]int)
+	}
+	fields, ok := c.fields[t]
+	if !ok {
+		fields = getStructFields(t)
+		c.fields[t] = fields
+		c.q.unmatched = unmatchedColumns(c.q.colSql, fields)
+	}
+	return c.setErr(scanStruct(c.q.rowSql, c.q.colSql, fields, target))
+}
+
+/** Err retorna el error ocurrido durante el recorrido, nil si las filas se recorrieron correctamente */
+func (c *Cursor) Err() error {
+	return c.err
+}
+
+/** Close cierra el resultado y libera la conexión, se puede llamar mas de una vez */
+func (c *Cursor) Close() error {
+	if !c.closed {
+		c.closed = true
+		c.q.closeRows()
+	}
+	return c.err
+}
+
+/** guarda el error de lectura y cierra el resultado */
+func (c *Cursor) setErr(err error) error {
+	if err != nil {
+		c.err = err
+		c.Close()
+	}
+	return err
+}
+
+/*
+EachCursor recorre el resultado de la consulta mediante un cursor del servidor (DECLARE ... FETCH),
+PostgreSQL envía las filas por bloques de batch filas en lugar de todo el resultado a la vez.
+
+Requiere la transacción abierta con Connect, la consulta no se ejecuta con Exec: EachCursor declara el cursor,
+lo recorre y lo cierra al terminar; la transacción se confirma con Close.
+
+Ejemplo de uso:
+
+	q := new(Querys).Connect(QConfig{Database: "mi_database"})
+	defer q.Close()
+	err := q.SetTable("requ_ventas").Select().EachCursor(1000, func(row map[string]interface{}) error {
+		return writer.Write(row)
+	})
+
+Parámetros:
+  - batch: cantidad de filas obtenidas en cada FETCH.
+  - fn: función que recibe cada fila como mapa columna => valor, si retorna un error se detiene el recorrido.
+
+Devuelve:
+  - El error retornado por fn o el ocurrido durante la ejecución.
+*/
+func (q *Querys) EachCursor(batch int, fn func(row map[string]interface{}) error) error {
+	if q.err != nil {
+		return q.err
+	}
+	if q.tx == nil {
+		return errors.New("EachCursor requiere una transacción abierta con Connect")
+	}
+	if batch <= 0 {
+		return errors.New("la cantidad de filas por bloque debe de ser mayor a cero")
+	}
+	ctx := q.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	name := fmt.Sprintf("basicgorm_cursor_%d", atomic.AddUint64(&cursorSeq, 1))
+	if _, err := q.tx.ExecContext(ctx, "DECLARE "+name+" NO SCROLL CURSOR FOR "+q.GetQuery(), q.getArgs()...); err != nil {
+		return fmt.Errorf("error sql declare: %w", contextError(ctx, err))
+	}
+	defer q.tx.ExecContext(ctx, "CLOSE "+name)
+
+	fetch := fmt.Sprintf("FETCH FORWARD %d FROM %s", batch, name)
+	for {
+		rows, err := q.tx.QueryContext(ctx, fetch)
+		if err != nil {
+			return fmt.Errorf("error sql fetch: %w", contextError(ctx, err))
+		}
+		n, err := eachRow(rows, q.decode, fn)
+		if err != nil {
+			return err
+		}
+		if n < batch {
+			return nil
+		}
+	}
+}
+
+/** recorre y cierra el resultado llamando a fn por cada fila, retorna la cantidad de filas leídas */
+func eachRow(rows *sql.Rows, options decodeOptions, fn func(row map[string]interface{}) error) (int, error) {
+	defer rows.Close()
+	cols, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+	decoders := columnDecoders(rows, options)
+	n := 0
+	for rows.Next() {
+		n++
+		row, err := scanMap(rows, cols, decoders)
+		if err != nil {
+			return n, err
+		}
+		if err := fn(row); err != nil {
+			return n, err
+		}
+	}
+	return n, canceledError(rows.Err())
+}
+
+/** lee la fila actual como mapa columna => valor aplicando las conversiones de las columnas */
+func scanMap(rows *sql.Rows, cols []string, decoders []columnDecoder) (map[string]interface{}, error) {
+	columns := make([]interface{}, len(cols))
+	columnPointers := make([]interface{}, len(cols))
+	for i := range columns {
+		columnPointers[i] = &columns[i]
+	}
+	if err := rows.Scan(columnPointers...); err != nil {
+		return nil, err
+	}
+	m := make(map[string]interface{}, len(cols))
+	for i, colName := range cols {
+		m[colName] = columns[i]
+	}
+	return m, decodeRow(m, cols, decoders)
+}
+
+/** lee la primera columna de la fila actual en dest descartando las demás */
+func scanFirst(rows *sql.Rows, ncols int, dest interface{}) error {
+	if ncols == 0 {
+		return errors.New("la consulta no retorno columnas")
+	}
+	pointers := make([]interface{}, ncols)
+	pointers[0] = dest
+	for i := 1; i < len(pointers); i++ {
+		pointers[i] = new(interface{})
+	}
+	return rows.Scan(pointers...)
+}
@@ -0,0 +1,68 @@
+package basicgorm
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+/** opciones de conversión de los valores de las columnas del resultado, se toman de QConfig */
+type decodeOptions struct {
+	numeric bool /** columnas numeric a Numeric */
+	json    bool /** columnas json y jsonb a map[string]interface{} o []interface{} */
+}
+
+func newDecodeOptions(config QConfig) decodeOptions {
+	return decodeOptions{numeric: config.DecodeNumeric, json: config.DecodeJSON}
+}
+
+/** convierte el valor leído de una columna, los valores NULL no se convierten */
+type columnDecoder func(value interface{}) (interface{}, error)
+
+/** retorna la conversión de cada columna del resultado según su tipo, nil si ninguna columna se convierte */
+func columnDecoders(rows *sql.Rows, options decodeOptions) []columnDecoder {
+	if !options.numeric && !options.json {
+		return nil
+	}
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		return nil
+	}
+	var decoders []columnDecoder
+	for i, t := range types {
+		var decoder columnDecoder
+		switch {
+		case options.numeric && t.DatabaseTypeName() == "NUMERIC":
+			decoder = decodeNumeric
+		case options.json && (t.DatabaseTypeName() == "JSON" || t.DatabaseTypeName() == "JSONB"):
+			decoder = decodeJSON
+		}
+		if decoder != nil {
+			if decoders == nil {
+				decoders = make([]columnDecoder, len(types))
+			}
+			decoders[i] = decoder
+		}
+	}
+	return decoders
+}
+
+/** aplica las conversiones de las columnas a la fila leída como mapa columna => valor */
+func decodeRow(row map[string]interface{}, cols []string, decoders []columnDecoder) error {
+	for i, decoder := range decoders {
+		if decoder == nil || row[cols[i]] == nil {
+			continue
+		}
+		value, err := decoder(row[cols[i]])
+		if err != nil {
+			return fmt.Errorf("columna %s: %w", cols[i], err)
+		}
+		row[cols[i]] = value
+	}
+	return nil
+}
+
+func decodeNumeric(value interface{}) (interface{}, error) {
+	var n Numeric
+	err := n.Scan(value)
+	return n, err
+}
@@ -0,0 +1,470 @@
+package basicgorm
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"strings"
+)
+
+/*
+Query es la versión tipada de Querys, utiliza el mismo constructor de consultas (WHERE, JOIN, ORDER BY, etc.)
+y retorna los resultados directamente como []T o T.
+
+T puede ser un struct (las columnas se relacionan mediante la etiqueta db, igual que en Querys.Scan)
+o un tipo simple (string, int64, time.Time, etc.), en cuyo caso se lee la primera columna del resultado.
+
+Ejemplo de uso:
+
+	type Cliente struct {
+		Documento string `db:"n_docu"`
+		Nombre    string `db:"l_clie"`
+	}
+	clientes, err := basicgorm.NewQuery[Cliente]("requ_clientes").
+		Where("c_ubig", basicgorm.I, "120119").
+		OrderBy("n_docu").
+		Find(basicgorm.QConfig{Database: "mi_database"})
+*/
+type Query[T any] struct {
+	q *Querys
+}
+
+/*
+NewQuery crea una consulta tipada sobre la tabla recibida, por defecto se seleccionan todas las columnas.
+
+Parámetros:
+  - table: nombre de la tabla, puede incluir alias (por ejemplo "requ_clientes as a").
+
+Devuelve:
+  - Un puntero a Query[T] para permitir el encadenamiento de métodos.
+*/
+func NewQuery[T any](table string) *Query[T] {
+	return &Query[T]{q: &Querys{Table: table}}
+}
+
+/*
+Builder retorna el Querys utilizado internamente, permite utilizar cualquier método del constructor de consultas.
+*/
+func (g *Query[T]) Builder() *Querys {
+	return g.q
+}
+
+/** With agrega una expresión de tabla común a la consulta, ver Querys.With */
+func (g *Query[T]) With(name string, sub *Querys) *Query[T] {
+	g.q.With(name, sub)
+	return g
+}
+
+/** Select establece las columnas de la consulta, ver Querys.Select */
+func (g *Query[T]) Select(campos ...string) *Query[T] {
+	g.q.Select(campos...)
+	return g
+}
+
+/** Join añade una cláusula JOIN a la consulta, ver Querys.Join */
+func (g *Query[T]) Join(tp TypeJoin, table string, on string) *Query[T] {
+	g.q.Join(tp, table, on)
+	return g
+}
+
+/** JoinQuery añade una cláusula JOIN con una subconsulta, ver Querys.JoinQuery */
+func (g *Query[T]) JoinQuery(tp TypeJoin, sub *Querys, alias string, on string) *Query[T] {
+	g.q.JoinQuery(tp, sub, alias, on)
+	return g
+}
+
+/** Where establece la cláusula WHERE de la consulta, ver Querys.Where */
+func (g *Query[T]) Where(where string, op OperatorWhere, arg interface{}) *Query[T] {
+	g.q.Where(where, op, arg)
+	return g
+}
+
+/** And añade una condición AND a la cláusula WHERE, ver Querys.And */
+func (g *Query[T]) And(and string, op OperatorWhere, arg interface{}) *Query[T] {
+	g.q.And(and, op, arg)
+	return g
+}
+
+/** Or añade una condición OR a la cláusula WHERE, ver Querys.Or */
+func (g *Query[T]) Or(or string, op OperatorWhere, arg interface{}) *Query[T] {
+	g.q.Or(or, op, arg)
+	return g
+}
+
+/** WhereGroup establece la cláusula WHERE con un grupo de condiciones, ver Querys.WhereGroup */
+func (g *Query[T]) WhereGroup(fn func(c *Cond)) *Query[T] {
+	g.q.WhereGroup(fn)
+	return g
+}
+
+/** AndGroup añade un grupo de condiciones unido con AND, ver Querys.AndGroup */
+func (g *Query[T]) AndGroup(fn func(c *Cond)) *Query[T] {
+	g.q.AndGroup(fn)
+	return g
+}
+
+/** OrGroup añade un grupo de condiciones unido con OR, ver Querys.OrGroup */
+func (g *Query[T]) OrGroup(fn func(c *Cond)) *Query[T] {
+	g.q.OrGroup(fn)
+	return g
+}
+
+/** Not niega un grupo de condiciones, ver Querys.Not */
+func (g *Query[T]) Not(fn func(c *Cond)) *Query[T] {
+	g.q.Not(fn)
+	return g
+}
+
+/** OrderBy establece la cláusula ORDER BY, ver Querys.OrderBy */
+func (g *Query[T]) OrderBy(campos ...string) *Query[T] {
+	g.q.OrderBy(campos...)
+	return g
+}
+
+/** GroupBy establece la cláusula GROUP BY, ver Querys.GroupBy */
+func (g *Query[T]) GroupBy(group ...string) *Query[T] {
+	g.q.GroupBy(group...)
+	return g
+}
+
+/** Having establece la cláusula HAVING, ver Querys.Having */
+func (g *Query[T]) Having(having string, op OperatorWhere, arg interface{}) *Query[T] {
+	g.q.Having(having, op, arg)
+	return g
+}
+
+/** AndHaving añade una condición AND a la cláusula HAVING, ver Querys.AndHaving */
+func (g *Query[T]) AndHaving(and string, op OperatorWhere, arg interface{}) *Query[T] {
+	g.q.AndHaving(and, op, arg)
+	return g
+}
+
+/** OrHaving añade una condición OR a la cláusula HAVING, ver Querys.OrHaving */
+func (g *Query[T]) OrHaving(or string, op OperatorWhere, arg interface{}) *Query[T] {
+	g.q.OrHaving(or, op, arg)
+	return g
+}
+
+/** Limit establece la cláusula LIMIT y OFFSET, ver Querys.Limit */
+func (g *Query[T]) Limit(limit ...int) *Query[T] {
+	g.q.Limit(limit...)
+	return g
+}
+
+/** GetQuery retorna la consulta SQL construida, ver Querys.GetQuery */
+func (g *Query[T]) GetQuery() string {
+	return g.q.GetQuery()
+}
+
+/*
+Find ejecuta la consulta y retorna todas las filas.
+
+Parámetros:
+  - config: Configuración para la conexión a la base de datos.
+
+Devuelve:
+  - Las filas de la consulta, un slice vacío si no existen resultados.
+  - Un error, si ocurre alguno durante la ejecución o lectura.
+*/
+func (g *Query[T]) Find(config QConfig) ([]T, error) {
+	return g.FindContext(context.Background(), config)
+}
+
+/** FindContext igual que Find utilizando el contexto recibido */
+func (g *Query[T]) FindContext(ctx context.Context, config QConfig) ([]T, error) {
+	if err := g.exec(ctx, config); err != nil {
+		return make([]T, 0), err
+	}
+	return g.readAll()
+}
+
+/*
+First ejecuta la consulta limitada a una fila y retorna la primera fila.
+
+Parámetros:
+  - config: Configuración para la conexión a la base de datos.
+
+Devuelve:
+  - La primera fila de la consulta.
+  - Un error, sql.ErrNoRows si la consulta no retorno filas.
+*/
+func (g *Query[T]) First(config QConfig) (T, error) {
+	return g.FirstContext(context.Background(), config)
+}
+
+/** FirstContext igual que First utilizando el contexto recibido */
+func (g *Query[T]) FirstContext(ctx context.Context, config QConfig) (T, error) {
+	var result T
+	top := g.q.query.Top
+	g.q.query.Top = " LIMIT 1"
+	/** se conserva el OFFSET establecido con Limit */
+	if i := strings.Index(top, " OFFSET "); i >= 0 {
+		g.q.query.Top += top[i:]
+	}
+	defer func() { g.q.query.Top = top }()
+
+	if err := g.exec(ctx, config); err != nil {
+		return result, err
+	}
+	found := false
+	err := scanTyped(g.q, func(v T) {
+		if !found {
+			result, found = v, true
+		}
+	})
+	if err == nil && !found {
+		err = sql.ErrNoRows
+	}
+	return result, err
+}
+
+/*
+Count retorna la cantidad de filas de la consulta ignorando ORDER BY y LIMIT.
+
+Parámetros:
+  - config: Configuración para la conexión a la base de datos.
+
+Devuelve:
+  - La cantidad de filas.
+  - Un error, si ocurre alguno durante la ejecución.
+*/
+func (g *Query[T]) Count(config QConfig) (int64, error) {
+	return g.CountContext(context.Background(), config)
+}
+
+/** CountContext igual que Count utilizando el contexto recibido */
+func (g *Query[T]) CountContext(ctx context.Context, config QConfig) (int64, error) {
+	return g.q.CountContext(ctx, config)
+}
+
+/** Paginate ejecuta la consulta limitada a la pagina recibida junto con el total de filas, ver Querys.Paginate */
+func (g *Query[T]) Paginate(config QConfig, page int, size int) (Page[T], error) {
+	return g.PaginateContext(context.Background(), config, page, size)
+}
+
+/** PaginateContext igual que Paginate utilizando el contexto recibido */
+func (g *Query[T]) PaginateContext(ctx context.Context, config QConfig, page int, size int) (Page[T], error) {
+	return paginate(ctx, g.q, config, page, size, g.readAll)
+}
+
+/** PaginateAfter retorna la pagina siguiente a los valores recibidos utilizando paginación por keyset, ver Querys.PaginateAfter */
+func (g *Query[T]) PaginateAfter(config QConfig, size int, after ...interface{}) (Page[T], error) {
+	return g.PaginateAfterContext(context.Background(), config, size, after...)
+}
+
+/** PaginateAfterContext igual que PaginateAfter utilizando el contexto recibido */
+func (g *Query[T]) PaginateAfterContext(ctx context.Context, config QConfig, size int, after ...interface{}) (Page[T], error) {
+	return paginateAfter(ctx, g.q, config, size, after, g.readAll)
+}
+
+/** ejecuta la consulta, si ocurre un error libera el resultado y la conexión antes de retornarlo */
+func (g *Query[T]) exec(ctx context.Context, config QConfig) error {
+	if err := g.q.ExecContext(ctx, config).GetErrors(); err != nil {
+		g.q.closeRows()
+		return err
+	}
+	return nil
+}
+
+/** lee todas las filas del resultado ejecutado */
+func (g *Query[T]) readAll() ([]T, error) {
+	result := make([]T, 0)
+	err := scanTyped(g.q, func(v T) {
+		result = append(result, v)
+	})
+	return result, err
+}
+
+/*
+TypedCursor recorre las filas de una consulta tipada una por una sin cargarlas todas en memoria,
+el resultado se cierra al terminar de recorrer las filas, al ocurrir un error o al llamar a Close.
+
+Ejemplo de uso:
+
+	rows, err := basicgorm.NewQuery[Cliente]("requ_clientes").Rows(basicgorm.QConfig{Database: "mi_database"})
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		cliente := rows.Value()
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+*/
+type TypedCursor[T any] struct {
+	cursor *Cursor
+	scan   func() (T, error)
+	value  T
+}
+
+/*
+Rows ejecuta la consulta y retorna un cursor tipado para recorrer sus filas.
+
+Parámetros:
+  - config: Configuración para la conexión a la base de datos.
+
+Devuelve:
+  - El cursor de las filas de la consulta.
+  - Un error, si ocurre alguno durante la ejecución.
+*/
+func (g *Query[T]) Rows(config QConfig) (*TypedCursor[T], error) {
+	return g.RowsContext(context.Background(), config)
+}
+
+/** RowsContext igual que Rows utilizando el contexto recibido */
+func (g *Query[T]) RowsContext(ctx context.Context, config QConfig) (*TypedCursor[T], error) {
+	if err := g.exec(ctx, config); err != nil {
+		return nil, err
+	}
+	scan, err := newTypedScanner[T](g.q)
+	if err != nil {
+		g.q.closeRows()
+		return nil, err
+	}
+	return &TypedCursor[T]{cursor: g.q.Rows(), scan: scan}, nil
+}
+
+/*
+Each ejecuta la consulta y llama a fn por cada fila, las filas se leen una por una sin cargarlas todas en memoria.
+
+Parámetros:
+  - config: Configuración para la conexión a la base de datos.
+  - fn: función que recibe cada fila, si retorna un error se detiene el recorrido.
+
+Devuelve:
+  - El error retornado por fn o el ocurrido durante la ejecución o lectura.
+*/
+func (g *Query[T]) Each(config QConfig, fn func(T) error) error {
+	return g.EachContext(context.Background(), config, fn)
+}
+
+/** EachContext igual que Each utilizando el contexto recibido */
+func (g *Query[T]) EachContext(ctx context.Context, config QConfig, fn func(T) error) error {
+	rows, err := g.RowsContext(ctx, config)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := fn(rows.Value()); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+/** Next avanza y lee la siguiente fila, retorna false al terminar o al ocurrir un error (ver Err) */
+func (c *TypedCursor[T]) Next() bool {
+	if !c.cursor.Next() {
+		return false
+	}
+	v, err := c.scan()
+	if c.cursor.setErr(err) != nil {
+		return false
+	}
+	c.value = v
+	return true
+}
+
+/** Value retorna la fila leída por el ultimo Next */
+func (c *TypedCursor[T]) Value() T {
+	return c.value
+}
+
+/** Err retorna el error ocurrido durante el recorrido, nil si las filas se recorrieron correctamente */
+func (c *TypedCursor[T]) Err() error {
+	return c.cursor.Err()
+}
+
+/** Close cierra el resultado y libera la conexión, se puede llamar mas de una vez */
+func (c *TypedCursor[T]) Close() error {
+	return c.cursor.Close()
+}
+
+/*
+Pluck ejecuta la consulta seleccionando solo la columna recibida y retorna sus valores como []V.
+
+Ejemplo de uso:
+
+	documentos, err := basicgorm.Pluck[Cliente, string](query, basicgorm.QConfig{Database: "mi_database"}, "n_docu")
+
+Parámetros:
+  - g: consulta tipada de la cual se toman la tabla y los filtros.
+  - config: Configuración para la conexión a la base de datos.
+  - column: columna o expresión a seleccionar.
+
+Devuelve:
+  - Los valores de la columna.
+  - Un error, si ocurre alguno durante la ejecución o lectura.
+*/
+func Pluck[T any, V any](g *Query[T], config QConfig, column string) ([]V, error) {
+	return PluckContext[T, V](context.Background(), g, config, column)
+}
+
+/** PluckContext igual que Pluck utilizando el contexto recibido */
+func PluckContext[T any, V any](ctx context.Context, g *Query[T], config QConfig, column string) ([]V, error) {
+	result := make([]V, 0)
+	selected := g.q.query.Select
+	g.q.Select(column)
+	defer func() { g.q.query.Select = selected }()
+
+	if err := g.exec(ctx, config); err != nil {
+		return result, err
+	}
+	err := scanTyped(g.q, func(v V) {
+		result = append(result, v)
+	})
+	return result, err
+}
+
+/** lee las filas del resultado de q en valores de tipo T, un struct o un tipo simple (primera columna) */
+func scanTyped[T any](q *Querys, add func(T)) error {
+	defer q.closeRows()
+	scan, err := newTypedScanner[T](q)
+	if err != nil {
+		return err
+	}
+	for q.rowSql.Next() {
+		v, err := scan()
+		if err != nil {
+			return err
+		}
+		add(v)
+	}
+	return canceledError(q.rowSql.Err())
+}
+
+/** retorna la función que lee la fila actual del resultado de q en un valor de tipo T */
+func newTypedScanner[T any](q *Querys) (func() (T, error), error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	structType := t
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if isScalarType(t) {
+		if len(q.colSql) == 0 {
+			return nil, errors.New("la consulta no retorno columnas")
+		}
+		return func() (T, error) {
+			var v T
+			err := scanFirst(q.rowSql, len(q.colSql), &v)
+			return v, err
+		}, nil
+	}
+
+	fields := getStructFields(structType)
+	q.unmatched = unmatchedColumns(q.colSql, fields)
+	return func() (T, error) {
+		var v T
+		target := reflect.ValueOf(&v).Elem()
+		if t.Kind() == reflect.Ptr {
+			target.Set(reflect.New(structType))
+			target = target.Elem()
+		}
+		err := scanStruct(q.rowSql, q.colSql, fields, target)
+		return v, err
+	}, nil
+}
@@ -0,0 +1,267 @@
+package basicgorm
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+/** nombre valido para tablas, esquemas, columnas y alias sin necesidad de comillas */
+var identRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_$]*$`)
+
+/** función de agregado sobre una columna: count(a.n_docu), sum(s_cant), etc. */
+var aggregateRegexp = regexp.MustCompile(`(?i)^(count|sum|avg|min|max)\(\s*(.+?)\s*\)$`)
+
+/** separa las condiciones de un ON unidas con AND */
+var onAndRegexp = regexp.MustCompile(`(?i)\s+and\s+`)
+
+/*
+CheckIdentifier verifica que el nombre sea un identificador valido (esquema.tabla.columna),
+cada parte solo puede contener letras, números, _ y $ y no puede iniciar con un número.
+
+Parámetros:
+  - name: identificador a validar, la ultima parte puede ser * (por ejemplo "a.*").
+
+Devuelve:
+  - Un error, si el identificador no es valido.
+*/
+func CheckIdentifier(name string) error {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		if part == "*" && i == len(parts)-1 {
+			continue
+		}
+		if !identRegexp.MatchString(part) {
+			return fmt.Errorf("identificador invalido: %q", name)
+		}
+	}
+	return nil
+}
+
+/*
+QuoteIdentifier valida el identificador y lo retorna entre comillas dobles por cada parte,
+por ejemplo ventas.clientes.n_docu => "ventas"."clientes"."n_docu".
+
+Los identificadores entre comillas distinguen mayúsculas de minúsculas en PostgreSQL.
+
+Parámetros:
+  - name: identificador a validar.
+
+Devuelve:
+  - El identificador entre comillas.
+  - Un error, si el identificador no es valido.
+*/
+func QuoteIdentifier(name string) (string, error) {
+	if err := CheckIdentifier(name); err != nil {
+		return "", err
+	}
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		if part != "*" {
+			parts[i] = `"` + part + `"`
+		}
+	}
+	return strings.Join(parts, "."), nil
+}
+
+/*
+*
+SafeIdentifiers activa el modo seguro de identificadores, a partir de este momento la tabla, columnas, JOIN,
+WHERE, ORDER BY y GROUP BY solo aceptan identificadores (esquema.tabla.columna con alias opcional),
+los cuales se validan y se entrecomillan, cualquier otra expresión se rechaza y se registra en GetErrors.
+
+Debe de llamarse antes de Select.
+
+Ejemplo de uso:
+
+	queryBuilder := &Querys{Table: "requ_clientes as a"}
+	queryBuilder.SafeIdentifiers().Select("a.n_docu", "a.l_clie as nombre").Where("a.c_ubig", I, ubigeo)
+	// SELECT "a"."n_docu","a"."l_clie" AS "nombre" FROM "requ_clientes" AS "a" WHERE "a"."c_ubig" = $1
+
+Devuelve:
+  - Un puntero al struct Querys actualizado para permitir el encadenamiento de métodos.
+*/
+func (q *Querys) SafeIdentifiers() *Querys {
+	q.safe = true
+	return q
+}
+
+/*
+*
+OrderBySafe agrega un campo a la cláusula ORDER BY solo si se encuentra en la lista de campos permitidos,
+pensado para ordenar según parámetros recibidos desde una API. Si ya existe una cláusula ORDER BY el campo se añade al final.
+
+Ejemplo de uso:
+
+	queryBuilder.Select().OrderBySafe(r.URL.Query().Get("sort"), r.URL.Query().Get("dir"), "n_docu", "l_clie", "f_naci")
+
+Parámetros:
+  - field: campo por el cual ordenar.
+  - dir: dirección del ordenamiento ASC o DESC (sin distinguir mayúsculas), si esta vació se utiliza ASC.
+  - allowed: lista de campos permitidos.
+
+Devuelve:
+  - Un puntero al struct Querys actualizado para permitir el encadenamiento de métodos.
+*/
+func (q *Querys) OrderBySafe(field string, dir string, allowed ...string) *Querys {
+	permitted := false
+	for _, v := range allowed {
+		if v == field {
+			permitted = true
+			break
+		}
+	}
+	if !permitted {
+		return q.setError(fmt.Errorf("campo no permitido para ordenar: %q", field))
+	}
+	switch strings.ToUpper(strings.TrimSpace(dir)) {
+	case "", "ASC":
+		dir = "ASC"
+	case "DESC":
+		dir = "DESC"
+	default:
+		return q.setError(fmt.Errorf("dirección de ordenamiento invalida: %q", dir))
+	}
+	column, err := q.ident(field)
+	if err != nil {
+		return q.setError(err)
+	}
+	if q.query.OrderBy == "" {
+		q.query.OrderBy = fmt.Sprintf(" ORDER BY %s %s", column, dir)
+	} else {
+		q.query.OrderBy += fmt.Sprintf(",%s %s", column, dir)
+	}
+	return q
+}
+
+/** registra el error del constructor de consultas, la consulta no se ejecutara correctamente hasta corregirlo */
+func (q *Querys) setError(err error) *Querys {
+	fmt.Println(err)
+	q.err, q.execErr = err, false
+	return q
+}
+
+/** valida el identificador, en modo seguro además lo entrecomilla */
+func (q *Querys) ident(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if q.safe {
+		return QuoteIdentifier(name)
+	}
+	if err := CheckIdentifier(name); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+/** entrecomilla un identificador o una función de agregado sobre un identificador (utilizada en HAVING) */
+func quoteAggregate(expr string) (string, error) {
+	expr = strings.TrimSpace(expr)
+	match := aggregateRegexp.FindStringSubmatch(expr)
+	if match == nil {
+		return QuoteIdentifier(expr)
+	}
+	if match[2] == "*" {
+		return strings.ToLower(match[1]) + "(*)", nil
+	}
+	column, err := QuoteIdentifier(match[2])
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s(%s)", strings.ToLower(match[1]), column), nil
+}
+
+/** entrecomilla un identificador con alias opcional: "tabla", "tabla a" o "tabla AS a" */
+func quoteAliased(expr string) (string, error) {
+	tokens := strings.Fields(expr)
+	if len(tokens) == 3 && strings.EqualFold(tokens[1], "as") {
+		tokens = []string{tokens[0], tokens[2]}
+	}
+	if len(tokens) == 0 || len(tokens) > 2 {
+		return "", fmt.Errorf("identificador invalido: %q", expr)
+	}
+	name, err := QuoteIdentifier(tokens[0])
+	if err != nil {
+		return "", err
+	}
+	if len(tokens) == 1 {
+		return name, nil
+	}
+	if !identRegexp.MatchString(tokens[1]) {
+		return "", fmt.Errorf("alias invalido: %q", expr)
+	}
+	return fmt.Sprintf(`%s AS "%s"`, name, tokens[1]), nil
+}
+
+/** entrecomilla la lista de columnas del SELECT, acepta columnas separadas por coma */
+func quoteColumns(campos []string) ([]string, error) {
+	var quoted []string
+	for _, campo := range campos {
+		for _, column := range strings.Split(campo, ",") {
+			column, err := quoteAliased(column)
+			if err != nil {
+				return nil, err
+			}
+			quoted = append(quoted, column)
+		}
+	}
+	return quoted, nil
+}
+
+/** entrecomilla la condición de un JOIN, solo acepta igualdades entre columnas unidas con AND */
+func quoteOn(on string) (string, error) {
+	var conditions []string
+	for _, condition := range onAndRegexp.Split(strings.TrimSpace(on), -1) {
+		sides := strings.Split(condition, "=")
+		if len(sides) != 2 {
+			return "", fmt.Errorf("condición ON invalida: %q", on)
+		}
+		left, err := QuoteIdentifier(strings.TrimSpace(sides[0]))
+		if err != nil {
+			return "", err
+		}
+		right, err := QuoteIdentifier(strings.TrimSpace(sides[1]))
+		if err != nil {
+			return "", err
+		}
+		conditions = append(conditions, left+" = "+right)
+	}
+	return strings.Join(conditions, " AND "), nil
+}
+
+/** entrecomilla los campos del ORDER BY, acepta ASC, DESC y NULLS FIRST/LAST */
+func quoteOrderBy(campos []string) ([]string, error) {
+	var quoted []string
+	for _, campo := range campos {
+		for _, item := range strings.Split(campo, ",") {
+			tokens := strings.Fields(item)
+			if len(tokens) == 0 {
+				return nil, fmt.Errorf("campo de ordenamiento invalido: %q", campo)
+			}
+			column, err := QuoteIdentifier(tokens[0])
+			if err != nil {
+				return nil, err
+			}
+			modifiers := strings.ToUpper(strings.Join(tokens[1:], " "))
+			switch modifiers {
+			case "", "ASC", "DESC", "NULLS FIRST", "NULLS LAST", "ASC NULLS FIRST", "ASC NULLS LAST", "DESC NULLS FIRST", "DESC NULLS LAST":
+			default:
+				return nil, fmt.Errorf("campo de ordenamiento invalido: %q", item)
+			}
+			quoted = append(quoted, strings.TrimSpace(column+" "+modifiers))
+		}
+	}
+	return quoted, nil
+}
+
+/** verifica la tabla y los nombres de los campos del esquema antes de generar INSERT, UPDATE o DELETE */
+func checkSchemaIdentifiers(table string, schema []Fields) error {
+	if err := CheckIdentifier(table); err != nil {
+		return err
+	}
+	for _, item := range schema {
+		if err := CheckIdentifier(item.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
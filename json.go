@@ -0,0 +1,215 @@
+package basicgorm
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+/*
+JSONSchema describe la estructura que debe cumplir el valor de un campo JSON, similar a JSON Schema
+(type, required, properties e items).
+
+Ejemplo de uso:
+
+	basicgorm.TypeJSON{
+		MaxDepth: 4,
+		Schema: &basicgorm.JSONSchema{
+			Type:     "object",
+			Required: []string{"direccion"},
+			Properties: map[string]*basicgorm.JSONSchema{
+				"direccion": {Type: "string"},
+				"telefonos": {Type: "array", Items: &basicgorm.JSONSchema{Type: "string"}},
+			},
+		},
+	}
+*/
+type JSONSchema struct {
+	Type       string                 //Tipo del valor: object, array, string, number, integer, boolean o null, vació acepta cualquier tipo
+	Required   []string               //Llaves obligatorias cuando el valor es object
+	Properties map[string]*JSONSchema //Esquema de las llaves del object, las llaves no descritas se aceptan sin validar
+	Items      *JSONSchema            //Esquema de los elementos cuando el valor es array
+}
+
+/*
+JSONField permite leer una columna json/jsonb en un campo de tipo struct, slice o mapa al utilizar Scan,
+y enviar el valor como JSON al utilizarlo en los argumentos de una consulta.
+
+Ejemplo de uso:
+
+	type Cliente struct {
+		Documento string                        `db:"n_docu"`
+		Contacto  basicgorm.JSONField[Contacto] `db:"j_cont"`
+	}
+*/
+type JSONField[T any] struct {
+	Data T
+}
+
+/** Scan decodifica el JSON de la columna en Data, NULL asigna el valor cero */
+func (j *JSONField[T]) Scan(src interface{}) error {
+	var data T
+	switch v := src.(type) {
+	case nil:
+	case []byte:
+		if err := json.Unmarshal(v, &data); err != nil {
+			return err
+		}
+	case string:
+		if err := json.Unmarshal([]byte(v), &data); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("no se puede asignar %T a JSONField", src)
+	}
+	j.Data = data
+	return nil
+}
+
+/** Value codifica Data como JSON */
+func (j JSONField[T]) Value() (driver.Value, error) {
+	b, err := json.Marshal(j.Data)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+/** MarshalJSON codifica solo Data */
+func (j JSONField[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(j.Data)
+}
+
+/** UnmarshalJSON decodifica el JSON en Data */
+func (j *JSONField[T]) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &j.Data)
+}
+
+/** convierte el valor recibido en JSON: texto o []byte con JSON valido, o cualquier valor que se pueda codificar (mapas, slices, structs) */
+func toJSON(value interface{}) (json.RawMessage, error) {
+	var raw []byte
+	switch v := value.(type) {
+	case json.RawMessage:
+		raw = v
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		return b, nil
+	}
+	if !json.Valid(raw) {
+		return nil, errors.New("JSON invalido")
+	}
+	return raw, nil
+}
+
+func caseJSON(value json.RawMessage, schema TypeJSON) (interface{}, []ValidationError) {
+	if schema.MaxSize > 0 && len(value) > schema.MaxSize {
+		return nil, []ValidationError{rule(CodeMaxSize, map[string]interface{}{"max": schema.MaxSize})}
+	}
+	if schema.MaxDepth > 0 || schema.Schema != nil {
+		decoder := json.NewDecoder(bytes.NewReader(value))
+		decoder.UseNumber()
+		var data interface{}
+		if err := decoder.Decode(&data); err != nil {
+			return nil, []ValidationError{rule(CodeInvalidType, nil)}
+		}
+		if schema.MaxDepth > 0 && jsonDepth(data) > schema.MaxDepth {
+			return nil, []ValidationError{rule(CodeMaxDepth, map[string]interface{}{"max": schema.MaxDepth})}
+		}
+		if rules := validateJSON(data, schema.Schema, "$"); len(rules) > 0 {
+			return nil, rules
+		}
+	}
+	/** se envía como texto para que PostgreSQL lo convierta a json/jsonb */
+	return string(value), nil
+}
+
+/** profundidad del valor, los valores simples tienen profundidad 0 */
+func jsonDepth(value interface{}) int {
+	depth := 0
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, item := range v {
+			depth = max(depth, jsonDepth(item))
+		}
+	case []interface{}:
+		for _, item := range v {
+			depth = max(depth, jsonDepth(item))
+		}
+	default:
+		return 0
+	}
+	return depth + 1
+}
+
+/** valida el valor con el esquema, path es la ruta del valor ($.llave[0]) utilizada en los errores */
+func validateJSON(value interface{}, schema *JSONSchema, path string) []ValidationError {
+	if schema == nil {
+		return nil
+	}
+	if schema.Type != "" && !jsonIsType(value, schema.Type) {
+		return []ValidationError{rule(CodeJSONType, map[string]interface{}{"path": path, "type": schema.Type})}
+	}
+	var rules []ValidationError
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, key := range schema.Required {
+			if _, ok := v[key]; !ok {
+				rules = append(rules, rule(CodeJSONRequired, map[string]interface{}{"path": path + "." + key}))
+			}
+		}
+		for key, property := range schema.Properties {
+			if item, ok := v[key]; ok {
+				rules = append(rules, validateJSON(item, property, path+"."+key)...)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			rules = append(rules, validateJSON(item, schema.Items, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	}
+	return rules
+}
+
+func jsonIsType(value interface{}, tp string) bool {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return tp == "object"
+	case []interface{}:
+		return tp == "array"
+	case string:
+		return tp == "string"
+	case bool:
+		return tp == "boolean"
+	case json.Number:
+		return tp == "number" || (tp == "integer" && !strings.ContainsAny(v.String(), ".eE"))
+	case nil:
+		return tp == "null"
+	}
+	return false
+}
+
+/** decodifica el JSON de una columna json/jsonb en map[string]interface{}, []interface{} o un valor simple */
+func decodeJSON(value interface{}) (interface{}, error) {
+	var raw []byte
+	switch v := value.(type) {
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		return value, nil
+	}
+	var data interface{}
+	err := json.Unmarshal(raw, &data)
+	return data, err
+}
@@ -2,6 +2,7 @@ package basicgorm
 
 import (
 	"regexp"
+	"time"
 )
 
 // Schema es una interfaz que define métodos para obtener información sobre el esquema de una tabla en una base de datos.
@@ -25,6 +26,10 @@ const (
 	String DataType = "string"
 	Time   DataType = "time"
 	Bytes  DataType = "bytes"
+	//Decimal número decimal exacto (numeric de PostgreSQL), el valor validado es de tipo Numeric
+	Decimal DataType = "decimal"
+	//JSON columnas json o jsonb, acepta mapas, slices, structs o texto con JSON
+	JSON DataType = "json"
 )
 
 /*
@@ -40,7 +45,7 @@ type Fields struct {
 	Update       bool        //El campo puede ser modificado
 	Default      interface{} //Valor por defecto que se tomara si no se le valor al campo, el tipo del valor debe de ser igual al Type del campo
 	Empty        bool        //El campo aceptara valor vació si se realiza la actualización
-	ValidateType interface{} //Los datos serán validados mas a fondo mediante esta opción para eso se le debe de asignar los siguientes typo de struct: TypeStrings, TypeFloat64, TypeUint64 yTypeInt64
+	ValidateType interface{} //Los datos serán validados mas a fondo mediante esta opción para eso se le debe de asignar los siguientes typo de struct: TypeStrings, TypeFloat64, TypeUint64, TypeInt64, TypeDecimal, TypeBool, TypeTime, TypeBytes y TypeJSON
 }
 
 type TypeStrings struct {
@@ -72,6 +77,52 @@ type TypeInt64 struct {
 	Negativo bool  // Rl campo aceptara valores negativos
 }
 
+/*
+TypeDecimal valida los campos Decimal, acepta Numeric, texto y números; los cálculos se realizan sin convertir a float64.
+*/
+type TypeDecimal struct {
+	Precision  int          //Cantidad máxima de dígitos del numero (como numeric(precision, scale)), en cero no se valida
+	Scale      int32        //Cantidad de decimales, los decimales adicionales se redondean con Rounding
+	Rounding   RoundingMode //Forma de redondear los decimales adicionales, por defecto RoundHalfUp
+	Porcentaje bool         //Convierte el valor del campo en porcentaje (se divide entre 100 de forma exacta)
+	Negativo   bool         //El campo aceptara valores negativos
+	Min        *Numeric     //Valor como mínimo que aceptara el campo
+	Max        *Numeric     //Valor como máximo que aceptara el campo
+}
+
+/*
+TypeBool valida los campos Bool, acepta bool, texto ("true", "false", "1", "0", etc.) y los números 0 y 1.
+*/
+type TypeBool struct {
+}
+
+/*
+TypeTime valida los campos Time, acepta time.Time o texto con alguno de los formatos de Layouts.
+*/
+type TypeTime struct {
+	Layouts  []string       //Formatos aceptados cuando el valor es texto, por defecto RFC3339, "2006-01-02 15:04:05", "2006-01-02" y "02/01/2006"
+	Min      time.Time      //Fecha mínima que aceptara el campo
+	Max      time.Time      //Fecha máxima que aceptara el campo
+	Location *time.Location //Zona horaria de los textos sin zona horaria y a la que se convierte el valor, por defecto UTC
+}
+
+/*
+TypeBytes valida los campos Bytes, acepta []byte o texto.
+*/
+type TypeBytes struct {
+	Max          int      //Tamaño máximo en bytes que aceptara el campo
+	ContentTypes []string //Tipos de contenido permitidos detectados con http.DetectContentType (por ejemplo "image/png" o "image/" para cualquier imagen)
+}
+
+/*
+TypeJSON valida los campos JSON, los mapas, slices y structs se codifican como JSON al insertar o actualizar.
+*/
+type TypeJSON struct {
+	MaxSize  int         //Tamaño máximo en bytes del JSON codificado
+	MaxDepth int         //Cantidad máxima de niveles de objetos y arrays anidados
+	Schema   *JSONSchema //Estructura que debe cumplir el valor
+}
+
 type Regex interface {
 	Letras(start int8, end int16) *regexp.Regexp
 	Float() *regexp.Regexp
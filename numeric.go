@@ -0,0 +1,274 @@
+package basicgorm
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+/*
+Numeric número decimal exacto (valor sin escala * 10^-escala) utilizado en los campos Decimal y en las columnas numeric de PostgreSQL,
+las operaciones no pasan por float64 por lo que no existen errores de redondeo (por ejemplo en montos de dinero).
+
+Implementa sql.Scanner y driver.Valuer, se puede utilizar como campo de los structs leídos con Scan.
+El valor cero (Numeric{}) es el número 0.
+
+Ejemplo de uso:
+
+	capital := basicgorm.MustParseNumeric("1500.50")
+	interes := capital.Mul(basicgorm.MustParseNumeric("0.025")).Round(2, basicgorm.RoundHalfEven) // 37.51
+*/
+type Numeric struct {
+	unscaled *big.Int
+	scale    int32
+}
+
+/** RoundingMode forma de redondear los decimales que superan la escala */
+type RoundingMode int
+
+const (
+	RoundHalfUp   RoundingMode = iota //Redondea al mas cercano, la mitad se aleja del cero (2.345 => 2.35)
+	RoundHalfEven                     //Redondea al mas cercano, la mitad va al numero par (redondeo bancario, 2.345 => 2.34)
+	RoundDown                         //Trunca los decimales (2.349 => 2.34)
+	RoundUp                           //Se aleja del cero si existen decimales (2.341 => 2.35)
+)
+
+/** limites del tipo numeric de PostgreSQL: dígitos de la parte entera y cantidad de decimales */
+const (
+	maxNumericDigits = 131072
+	maxNumericScale  = 16383
+)
+
+/*
+ParseNumeric convierte el texto en Numeric, acepta signo, decimales y exponente (por ejemplo "-1500.50" o "1.5e3").
+
+Devuelve:
+  - El numero.
+  - Un error, si el texto no es un numero valido o supera los limites de numeric (131072 dígitos enteros y 16383 decimales).
+*/
+func ParseNumeric(value string) (Numeric, error) {
+	s := strings.TrimSpace(value)
+	exp := 0
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.Atoi(s[i+1:])
+		if err != nil {
+			return Numeric{}, fmt.Errorf("numero decimal invalido: %s", value)
+		}
+		if e > maxNumericDigits+maxNumericScale || e < -(maxNumericDigits+maxNumericScale) {
+			return Numeric{}, fmt.Errorf("numero decimal fuera de rango: %s", value)
+		}
+		exp = e
+		s = s[:i]
+	}
+	digits := s
+	scale := 0
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		digits = s[:i] + s[i+1:]
+		scale = len(s) - i - 1
+	}
+	unsigned := strings.TrimLeft(digits, "+-")
+	if unsigned == "" || len(digits)-len(unsigned) > 1 || strings.ContainsAny(unsigned, "+-") {
+		return Numeric{}, fmt.Errorf("numero decimal invalido: %s", value)
+	}
+	unscaled, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return Numeric{}, fmt.Errorf("numero decimal invalido: %s", value)
+	}
+	scale -= exp
+	/** se valida antes de calcular la potencia, un exponente grande construiría un numero enorme */
+	if scale > maxNumericScale || len(strings.TrimLeft(unsigned, "0"))-scale > maxNumericDigits {
+		return Numeric{}, fmt.Errorf("numero decimal fuera de rango: %s", value)
+	}
+	if scale < 0 {
+		unscaled.Mul(unscaled, pow10(-scale))
+		scale = 0
+	}
+	return Numeric{unscaled: unscaled, scale: int32(scale)}, nil
+}
+
+/** MustParseNumeric igual que ParseNumeric, entra en pánico si el texto no es un numero valido (pensado para constantes) */
+func MustParseNumeric(value string) Numeric {
+	n, err := ParseNumeric(value)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+/** NewNumeric crea el numero unscaled * 10^-scale, por ejemplo NewNumeric(150050, 2) es 1500.50 */
+func NewNumeric(unscaled int64, scale int32) Numeric {
+	if scale < 0 {
+		return Numeric{unscaled: new(big.Int).Mul(big.NewInt(unscaled), pow10(int(-scale)))}
+	}
+	return Numeric{unscaled: big.NewInt(unscaled), scale: scale}
+}
+
+func (n Numeric) int() *big.Int {
+	if n.unscaled == nil {
+		return new(big.Int)
+	}
+	return n.unscaled
+}
+
+/** Scale retorna la cantidad de decimales del numero */
+func (n Numeric) Scale() int32 {
+	return n.scale
+}
+
+/** Sign retorna -1, 0 o 1 según el signo del numero */
+func (n Numeric) Sign() int {
+	return n.int().Sign()
+}
+
+/** Cmp compara los números, retorna -1 si n < x, 0 si son iguales y 1 si n > x */
+func (n Numeric) Cmp(x Numeric) int {
+	a, b := align(n, x)
+	return a.Cmp(b)
+}
+
+/** Add retorna n + x */
+func (n Numeric) Add(x Numeric) Numeric {
+	a, b := align(n, x)
+	return Numeric{unscaled: new(big.Int).Add(a, b), scale: max(n.scale, x.scale)}
+}
+
+/** Sub retorna n - x */
+func (n Numeric) Sub(x Numeric) Numeric {
+	a, b := align(n, x)
+	return Numeric{unscaled: new(big.Int).Sub(a, b), scale: max(n.scale, x.scale)}
+}
+
+/** Mul retorna n * x, la escala del resultado es la suma de las escalas */
+func (n Numeric) Mul(x Numeric) Numeric {
+	return Numeric{unscaled: new(big.Int).Mul(n.int(), x.int()), scale: n.scale + x.scale}
+}
+
+/** Neg retorna -n */
+func (n Numeric) Neg() Numeric {
+	return Numeric{unscaled: new(big.Int).Neg(n.int()), scale: n.scale}
+}
+
+/** Round retorna el numero con la escala recibida, si tiene mas decimales se redondean con el modo recibido, una escala negativa redondea a decenas, centenas, etc. (Round(-1) de 15 es 20) */
+func (n Numeric) Round(scale int32, mode RoundingMode) Numeric {
+	if scale >= n.scale {
+		return Numeric{unscaled: new(big.Int).Mul(n.int(), pow10(int(scale-n.scale))), scale: scale}
+	}
+	divisor := pow10(int(n.scale - scale))
+	q, r := new(big.Int).QuoRem(n.int(), divisor, new(big.Int))
+	if r.Sign() != 0 {
+		var away bool
+		switch mode {
+		case RoundUp:
+			away = true
+		case RoundDown:
+			away = false
+		default:
+			half := new(big.Int).Abs(r)
+			half.Mul(half, big.NewInt(2))
+			c := half.Cmp(divisor)
+			away = c > 0 || (c == 0 && (mode == RoundHalfUp || q.Bit(0) == 1))
+		}
+		if away {
+			q.Add(q, big.NewInt(int64(n.int().Sign())))
+		}
+	}
+	if scale < 0 {
+		/** la escala negativa no se conserva, String solo imprime escalas desde 0 */
+		return Numeric{unscaled: q.Mul(q, pow10(int(-scale)))}
+	}
+	return Numeric{unscaled: q, scale: scale}
+}
+
+/** String retorna el numero en texto con todos sus decimales, por ejemplo "1500.50" */
+func (n Numeric) String() string {
+	digits := new(big.Int).Abs(n.int()).String()
+	sign := ""
+	if n.Sign() < 0 {
+		sign = "-"
+	}
+	if n.scale <= 0 {
+		return sign + digits
+	}
+	scale := int(n.scale)
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+}
+
+/** Float64 retorna el valor aproximado como float64 */
+func (n Numeric) Float64() float64 {
+	f, _ := strconv.ParseFloat(n.String(), 64)
+	return f
+}
+
+/** Value envía el numero a la base de datos como texto, sin perder precisión */
+func (n Numeric) Value() (driver.Value, error) {
+	return n.String(), nil
+}
+
+/** Scan lee el valor de una columna numeric (texto), entera o real */
+func (n *Numeric) Scan(src interface{}) error {
+	var err error
+	switch v := src.(type) {
+	case []byte:
+		*n, err = ParseNumeric(string(v))
+	case string:
+		*n, err = ParseNumeric(v)
+	case int64:
+		*n = NewNumeric(v, 0)
+	case float64:
+		*n, err = ParseNumeric(strconv.FormatFloat(v, 'f', -1, 64))
+	case nil:
+		return errors.New("no se puede asignar NULL a Numeric, utilice *Numeric")
+	default:
+		return fmt.Errorf("no se puede asignar %T a Numeric", src)
+	}
+	return err
+}
+
+/** MarshalJSON codifica el numero como numero JSON con todos sus decimales */
+func (n Numeric) MarshalJSON() ([]byte, error) {
+	return []byte(n.String()), nil
+}
+
+/** UnmarshalJSON acepta el numero como numero JSON o como texto, null no modifica el valor */
+func (n *Numeric) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	value, err := ParseNumeric(strings.Trim(string(data), `"`))
+	if err != nil {
+		return err
+	}
+	*n = value
+	return nil
+}
+
+/** cantidad de dígitos de la parte entera */
+func (n Numeric) integerDigits() int {
+	digits := len(new(big.Int).Abs(n.int()).String()) - int(n.scale)
+	if digits < 0 || n.Sign() == 0 {
+		return 0
+	}
+	return digits
+}
+
+/** retorna los valores sin escala de ambos números llevados a la misma escala */
+func align(a Numeric, b Numeric) (*big.Int, *big.Int) {
+	switch {
+	case a.scale > b.scale:
+		return a.int(), new(big.Int).Mul(b.int(), pow10(int(a.scale-b.scale)))
+	case b.scale > a.scale:
+		return new(big.Int).Mul(a.int(), pow10(int(b.scale-a.scale))), b.int()
+	default:
+		return a.int(), b.int()
+	}
+}
+
+func pow10(exp int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil)
+}
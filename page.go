@@ -0,0 +1,314 @@
+package basicgorm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+/*
+Page contiene las filas de una pagina de resultados junto con la información de la paginación.
+
+En la paginación por keyset (PaginateAfter) Page es cero y After contiene los valores de las columnas
+del ORDER BY de la ultima fila, los cuales se envían para obtener la siguiente pagina.
+*/
+type Page[T any] struct {
+	Rows    []T           `json:"rows"`     //Filas de la pagina
+	Total   int64         `json:"total"`    //Cantidad total de filas de la consulta
+	Page    int           `json:"page"`     //Numero de la pagina, inicia en 1
+	Size    int           `json:"size"`     //Cantidad máxima de filas por pagina
+	Pages   int           `json:"pages"`    //Cantidad total de paginas
+	HasNext bool          `json:"has_next"` //Indica si existe una pagina siguiente
+	HasPrev bool          `json:"has_prev"` //Indica si existe una pagina anterior
+	After   []interface{} `json:"after"`    //Valores de las columnas del ORDER BY de la ultima fila (keyset)
+}
+
+/** columna de la cláusula ORDER BY utilizada en la paginación por keyset */
+type orderColumn struct {
+	expr string /** expresión de la columna tal como se encuentra en el ORDER BY */
+	key  string /** nombre de la columna en el resultado */
+	desc bool
+}
+
+/*
+Paginate ejecuta la consulta limitada a la pagina recibida (LIMIT/OFFSET) y retorna sus filas junto con
+el total de filas, obtenido con un COUNT(*) sobre la misma consulta ignorando ORDER BY y LIMIT.
+
+Ejemplo de uso:
+
+	page, err := new(basicgorm.Querys).SetTable("requ_clientes").Select().Where("c_ubig", basicgorm.I, "120119").
+		OrderBy("n_docu").Paginate(basicgorm.QConfig{Database: "mi_database"}, 2, 50)
+
+Parámetros:
+  - config: Configuración para la conexión a la base de datos.
+  - page: numero de la pagina, inicia en 1.
+  - size: cantidad de filas por pagina.
+
+Devuelve:
+  - La pagina con las filas como mapas columna => valor.
+  - Un error, si ocurre alguno durante el conteo o la ejecución.
+*/
+func (q *Querys) Paginate(config QConfig, page int, size int) (Page[map[string]interface{}], error) {
+	return q.PaginateContext(context.Background(), config, page, size)
+}
+
+/** PaginateContext igual que Paginate utilizando el contexto recibido */
+func (q *Querys) PaginateContext(ctx context.Context, config QConfig, page int, size int) (Page[map[string]interface{}], error) {
+	return paginate(ctx, q, config, page, size, q.All)
+}
+
+/*
+PaginateAfter retorna la pagina siguiente a los valores recibidos utilizando paginación por keyset (seek),
+en lugar de OFFSET filtra las filas posteriores a la ultima fila de la pagina anterior según las columnas del ORDER BY,
+por lo que el tiempo de respuesta no aumenta en las paginas profundas.
+
+Las columnas del ORDER BY deben de estar en el SELECT, no admitir NULL y juntas identificar a una sola fila (por ejemplo incluir la llave primaria).
+No admite GROUP BY, HAVING, consultas combinadas ni consultas directas (SetQueryString).
+
+Ejemplo de uso:
+
+	query := new(basicgorm.Querys).SetTable("requ_clientes").Select().OrderBy("f_regi DESC", "n_docu")
+	page, err := query.PaginateAfter(config, 50)                 // primera pagina
+	page, err = query.PaginateAfter(config, 50, page.After...)   // siguiente pagina
+
+Parámetros:
+  - config: Configuración para la conexión a la base de datos.
+  - size: cantidad de filas por pagina.
+  - after: valores de las columnas del ORDER BY de la ultima fila de la pagina anterior (Page.After), vació para la primera pagina.
+
+Devuelve:
+  - La pagina con las filas como mapas columna => valor.
+  - Un error, si ocurre alguno durante el conteo o la ejecución.
+*/
+func (q *Querys) PaginateAfter(config QConfig, size int, after ...interface{}) (Page[map[string]interface{}], error) {
+	return q.PaginateAfterContext(context.Background(), config, size, after...)
+}
+
+/** PaginateAfterContext igual que PaginateAfter utilizando el contexto recibido */
+func (q *Querys) PaginateAfterContext(ctx context.Context, config QConfig, size int, after ...interface{}) (Page[map[string]interface{}], error) {
+	return paginateAfter(ctx, q, config, size, after, q.All)
+}
+
+/** ejecuta la consulta limitada a la pagina, read lee las filas del resultado ejecutado */
+func paginate[T any](ctx context.Context, q *Querys, config QConfig, page int, size int, read func() ([]T, error)) (Page[T], error) {
+	result := Page[T]{Rows: make([]T, 0), Page: page, Size: size}
+	if page <= 0 || size <= 0 {
+		return result, errors.New("la pagina y la cantidad de filas por pagina deben de ser mayores a cero")
+	}
+	total, err := q.CountContext(ctx, config)
+	if err != nil {
+		return result, err
+	}
+	result.setTotal(total)
+	result.HasNext = page < result.Pages
+	result.HasPrev = page > 1
+
+	top := q.query.Top
+	q.query.Top = fmt.Sprintf(" LIMIT %d OFFSET %d", size, (page-1)*size)
+	defer func() { q.query.Top = top }()
+	/** libera el resultado también cuando la ejecución o la lectura fallan */
+	defer q.closeRows()
+	if err := q.ExecContext(ctx, config).GetErrors(); err != nil {
+		return result, err
+	}
+	rows, err := read()
+	if err != nil {
+		return result, err
+	}
+	result.Rows = rows
+	return result, nil
+}
+
+/** ejecuta la consulta filtrando las filas posteriores a after según las columnas del ORDER BY */
+func paginateAfter[T any](ctx context.Context, q *Querys, config QConfig, size int, after []interface{}, read func() ([]T, error)) (Page[T], error) {
+	result := Page[T]{Rows: make([]T, 0), Size: size}
+	if size <= 0 {
+		return result, errors.New("la cantidad de filas por pagina debe de ser mayor a cero")
+	}
+	if q.query.workQueryFull || q.query.GroupBy != "" || q.query.Having != "" || len(q.query.Compound) > 0 {
+		return result, errors.New("la paginación por keyset no admite GROUP BY, HAVING, consultas combinadas ni consultas directas")
+	}
+	columns, err := parseOrderBy(q.query.OrderBy)
+	if err != nil {
+		return result, err
+	}
+	if len(after) > 0 && len(after) != len(columns) {
+		return result, fmt.Errorf("se esperaban %d valores para las columnas del ORDER BY, se recibieron %d", len(columns), len(after))
+	}
+	total, err := q.CountContext(ctx, config)
+	if err != nil {
+		return result, err
+	}
+	result.setTotal(total)
+	result.HasPrev = len(after) > 0
+
+	where, args, argsLen, top := q.query.Where, q.args, q.argsLen, q.query.Top
+	defer func() {
+		q.query.Where, q.args, q.argsLen, q.query.Top = where, args, argsLen, top
+	}()
+	if len(after) > 0 {
+		q.args = append([]interface{}{}, args...)
+		condition := keysetCondition(q, columns, after)
+		if where == "" {
+			q.query.Where = " WHERE " + condition
+		} else {
+			q.query.Where = fmt.Sprintf(" WHERE (%s) AND %s", strings.TrimPrefix(where, " WHERE "), condition)
+		}
+	}
+	/** se obtiene una fila adicional para saber si existe una pagina siguiente */
+	q.query.Top = fmt.Sprintf(" LIMIT %d", size+1)
+	defer q.closeRows()
+	if err := q.ExecContext(ctx, config).GetErrors(); err != nil {
+		return result, err
+	}
+	types := keysetTypes(q)
+	rows, err := read()
+	if err != nil {
+		return result, err
+	}
+	if len(rows) > size {
+		rows = rows[:size]
+		result.HasNext = true
+	}
+	result.Rows = rows
+	if len(rows) > 0 {
+		if result.After, err = keysetValues(rows[len(rows)-1], columns, types); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+func (p *Page[T]) setTotal(total int64) {
+	p.Total = total
+	p.Pages = int((total + int64(p.Size) - 1) / int64(p.Size))
+}
+
+/*
+keysetCondition genera la condición que filtra las filas posteriores a los valores recibidos,
+por ejemplo para ORDER BY a DESC, b: (a < $1 OR (a = $2 AND b > $3)).
+*/
+func keysetCondition(q *Querys, columns []orderColumn, after []interface{}) string {
+	var or []string
+	for i, column := range columns {
+		var and []string
+		for j := 0; j < i; j++ {
+			and = append(and, fmt.Sprintf("%s = %s", columns[j].expr, q.bind(after[j])))
+		}
+		op := ">"
+		if column.desc {
+			op = "<"
+		}
+		and = append(and, fmt.Sprintf("%s %s %s", column.expr, op, q.bind(after[i])))
+		if len(and) == 1 {
+			or = append(or, and[0])
+		} else {
+			or = append(or, "("+strings.Join(and, " AND ")+")")
+		}
+	}
+	return "(" + strings.Join(or, " OR ") + ")"
+}
+
+/** retorna el tipo de la base de datos de cada columna del resultado ejecutado, columna => tipo */
+func keysetTypes(q *Querys) map[string]string {
+	types := make(map[string]string)
+	if q.rowSql == nil {
+		return types
+	}
+	columnTypes, err := q.rowSql.ColumnTypes()
+	if err != nil {
+		return types
+	}
+	for _, t := range columnTypes {
+		types[t.Name()] = t.DatabaseTypeName()
+	}
+	return types
+}
+
+/*
+keysetParam convierte el valor leído de la fila al tipo de su columna para enviarlo como parámetro de la siguiente pagina,
+lib/pq retorna como []byte los NUMERIC y los tipos sin conversión propia (uuid, etc.)
+*/
+func keysetParam(value interface{}, dbType string) (interface{}, error) {
+	b, ok := value.([]byte)
+	if !ok {
+		return value, nil
+	}
+	switch dbType {
+	case "BYTEA":
+		return value, nil
+	case "NUMERIC":
+		return decodeNumeric(b)
+	}
+	return string(b), nil
+}
+
+/** retorna los valores de las columnas del ORDER BY de la fila, un mapa columna => valor o un struct */
+func keysetValues(row interface{}, columns []orderColumn, types map[string]string) ([]interface{}, error) {
+	values := make([]interface{}, len(columns))
+	if m, ok := row.(map[string]interface{}); ok {
+		for i, column := range columns {
+			value, ok := m[column.key]
+			if !ok {
+				return nil, fmt.Errorf("la columna %s del ORDER BY debe de estar en el SELECT", column.key)
+			}
+			param, err := keysetParam(value, types[column.key])
+			if err != nil {
+				return nil, fmt.Errorf("columna %s: %w", column.key, err)
+			}
+			values[i] = param
+		}
+		return values, nil
+	}
+
+	v := reflect.ValueOf(row)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct || isScalarType(v.Type()) {
+		return nil, errors.New("la paginación por keyset requiere filas de tipo struct o mapa")
+	}
+	fields := getStructFields(v.Type())
+	for i, column := range columns {
+		index, ok := fields[strings.ToLower(column.key)]
+		if !ok {
+			return nil, fmt.Errorf("la columna %s del ORDER BY no tiene un campo en el struct", column.key)
+		}
+		values[i] = v.FieldByIndex(index).Interface()
+	}
+	return values, nil
+}
+
+/** separa la cláusula ORDER BY en sus columnas y dirección, las columnas deben de ser simples (tabla.columna) */
+func parseOrderBy(orderBy string) ([]orderColumn, error) {
+	orderBy = strings.TrimPrefix(orderBy, " ORDER BY ")
+	if strings.TrimSpace(orderBy) == "" {
+		return nil, errors.New("la paginación por keyset requiere la cláusula ORDER BY")
+	}
+	var columns []orderColumn
+	for _, campo := range strings.Split(orderBy, ",") {
+		parts := strings.Fields(campo)
+		column := orderColumn{}
+		if len(parts) == 2 {
+			switch strings.ToUpper(parts[1]) {
+			case "DESC":
+				column.desc = true
+			case "ASC":
+			default:
+				return nil, fmt.Errorf("la columna %s del ORDER BY no es valida para la paginación por keyset", strings.TrimSpace(campo))
+			}
+		} else if len(parts) != 1 {
+			return nil, fmt.Errorf("la columna %s del ORDER BY no es valida para la paginación por keyset", strings.TrimSpace(campo))
+		}
+		column.expr = parts[0]
+		column.key = column.expr[strings.LastIndex(column.expr, ".")+1:]
+		column.key = strings.Trim(column.key, `"`)
+		if column.key == "" || strings.ContainsAny(column.expr, "()") {
+			return nil, fmt.Errorf("la columna %s del ORDER BY no es valida para la paginación por keyset", strings.TrimSpace(campo))
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
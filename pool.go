@@ -0,0 +1,202 @@
+package basicgorm
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+/*
+PoolConfig define los límites del pool de conexiones compartido que se crea por cada base de datos.
+
+Los valores en cero utilizan los valores por defecto (25 conexiones abiertas, 25 inactivas, 30 minutos de vida
+y 5 minutos inactivas), los valores negativos quitan el límite (MaxIdleConns negativo no conserva conexiones inactivas).
+*/
+type PoolConfig struct {
+	MaxOpenConns    int           //Cantidad máxima de conexiones abiertas por base de datos
+	MaxIdleConns    int           //Cantidad máxima de conexiones inactivas que se mantienen en el pool
+	ConnMaxLifetime time.Duration //Tiempo máximo que una conexión puede ser reutilizada
+	ConnMaxIdleTime time.Duration //Tiempo máximo que una conexión puede permanecer inactiva
+	StmtCacheSize   int           //Cantidad de sentencias preparadas que se reutilizan por pool (100 por defecto), negativo no se reutilizan
+}
+
+/** registro de pools compartidos por todo el proceso, la llave es el hash de la cadena de conexión */
+var (
+	poolMutex         sync.Mutex
+	pools             = make(map[string]*sql.DB)
+	stmtCaches        = make(map[*sql.DB]*stmtCache)
+	poolConfig        = defaultPoolConfig
+	defaultPoolConfig = PoolConfig{
+		MaxOpenConns:    25,
+		MaxIdleConns:    25,
+		ConnMaxLifetime: 30 * time.Minute,
+		ConnMaxIdleTime: 5 * time.Minute,
+		StmtCacheSize:   100,
+	}
+)
+
+/*
+SetPoolConfig establece la configuración de los pools de conexiones.
+
+La configuración se aplica a los pools ya abiertos y a los que se abran posteriormente.
+
+Parámetros:
+  - config: límites de conexiones y tiempos de vida de las conexiones, los campos en cero toman el valor por defecto.
+*/
+func SetPoolConfig(config PoolConfig) {
+	config = config.withDefaults()
+	poolMutex.Lock()
+	defer poolMutex.Unlock()
+	poolConfig = config
+	for _, db := range pools {
+		applyPoolConfig(db, config)
+		stmtCaches[db].setSize(config.StmtCacheSize)
+	}
+}
+
+/*
+GetPool retorna el pool de conexiones compartido para la configuración recibida, si aun no existe lo crea.
+
+El *sql.DB retornado es compartido por todo el proceso, no debe ser cerrado por quien lo utiliza;
+para liberar las conexiones se debe de utilizar ClosePool o ClosePools.
+
+Parámetros:
+  - config: configuración de la conexión (Cloud, Database o Config).
+
+Devuelve:
+  - El pool de conexiones de la base de datos.
+  - Un error, si no se pudo establecer la conexión.
+*/
+func GetPool(config QConfig) (*sql.DB, error) {
+	return GetPoolContext(context.Background(), config)
+}
+
+/** GetPoolContext igual que GetPool, si el pool aun no existe la conexión respeta la cancelación y el tiempo limite del contexto */
+func GetPoolContext(ctx context.Context, config QConfig) (*sql.DB, error) {
+	db, _, err := getPool(ctx, config)
+	return db, err
+}
+
+/** retorna el pool de conexiones junto con su cache de sentencias preparadas */
+func getPool(ctx context.Context, config QConfig) (*sql.DB, *stmtCache, error) {
+	key, cnnConfig, err := poolKey(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	poolMutex.Lock()
+	if db, ok := pools[key]; ok {
+		poolMutex.Unlock()
+		return db, stmtCaches[db], nil
+	}
+	poolMutex.Unlock()
+
+	/** la conexión se establece sin bloquear el registro, un servidor lento no detiene a los demás pools */
+	db, err := ConnectionConfigContext(ctx, cnnConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	poolMutex.Lock()
+	defer poolMutex.Unlock()
+	if existing, ok := pools[key]; ok {
+		/** otra consulta creo el pool mientras tanto */
+		db.Close()
+		return existing, stmtCaches[existing], nil
+	}
+	applyPoolConfig(db, poolConfig)
+	pools[key] = db
+	stmtCaches[db] = newStmtCache(db, poolConfig.StmtCacheSize)
+	return db, stmtCaches[db], nil
+}
+
+/*
+ClosePool cierra el pool de conexiones de la configuración recibida y lo elimina del registro.
+
+Parámetros:
+  - config: configuración de la conexión (Cloud, Database o Config).
+
+Devuelve:
+  - Un error, si ocurre alguno al cerrar las conexiones.
+*/
+func ClosePool(config QConfig) error {
+	key, _, err := poolKey(config)
+	if err != nil {
+		return err
+	}
+	poolMutex.Lock()
+	defer poolMutex.Unlock()
+	db, ok := pools[key]
+	if !ok {
+		return nil
+	}
+	delete(pools, key)
+	stmtCaches[db].close()
+	delete(stmtCaches, db)
+	return db.Close()
+}
+
+/*
+ClosePools cierra todos los pools de conexiones abiertos, pensado para ser llamado al finalizar el proceso.
+
+Devuelve:
+  - Los errores ocurridos al cerrar las conexiones.
+*/
+func ClosePools() error {
+	poolMutex.Lock()
+	defer poolMutex.Unlock()
+	var errs []error
+	for key, db := range pools {
+		stmtCaches[db].close()
+		delete(stmtCaches, db)
+		if err := db.Close(); err != nil {
+			errs = append(errs, err)
+		}
+		delete(pools, key)
+	}
+	return errors.Join(errs...)
+}
+
+/** retorna la llave del registro (hash de la cadena de conexión, no contiene la contraseña) y los datos de conexión */
+func poolKey(config QConfig) (string, Config, error) {
+	cnnConfig, err := config.getConfig()
+	if err != nil {
+		if config.Cloud {
+			return "", Config{}, fmt.Errorf("error al obtener configuración del servidor cloud: %w", err)
+		}
+		return "", Config{}, fmt.Errorf("error al obtener configuración del servidor: %w", err)
+	}
+	sum := sha256.Sum256([]byte(cnnConfig.DSN()))
+	return hex.EncodeToString(sum[:]), cnnConfig, nil
+}
+
+/** reemplaza los campos en cero por los valores por defecto */
+func (c PoolConfig) withDefaults() PoolConfig {
+	if c.MaxOpenConns == 0 {
+		c.MaxOpenConns = defaultPoolConfig.MaxOpenConns
+	}
+	if c.MaxIdleConns == 0 {
+		c.MaxIdleConns = defaultPoolConfig.MaxIdleConns
+	}
+	if c.ConnMaxLifetime == 0 {
+		c.ConnMaxLifetime = defaultPoolConfig.ConnMaxLifetime
+	}
+	if c.ConnMaxIdleTime == 0 {
+		c.ConnMaxIdleTime = defaultPoolConfig.ConnMaxIdleTime
+	}
+	if c.StmtCacheSize == 0 {
+		c.StmtCacheSize = defaultPoolConfig.StmtCacheSize
+	}
+	return c
+}
+
+func applyPoolConfig(db *sql.DB, config PoolConfig) {
+	db.SetMaxOpenConns(config.MaxOpenConns)
+	db.SetMaxIdleConns(config.MaxIdleConns)
+	db.SetConnMaxLifetime(config.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(config.ConnMaxIdleTime)
+}
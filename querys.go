@@ -3,41 +3,66 @@ package basicgorm
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
+
+	"github.com/lib/pq"
 )
 
 type QConfig struct {
 	Cloud     bool
 	Database  string
 	Procedure bool
+	Config    *Config       /** datos de conexión, si es nil se utiliza la configuración por defecto (SetDefaultConfig o variables de entorno) */
+	Timeout   time.Duration /** tiempo limite de la consulta, al superarlo se cancela y se retorna ErrQueryCanceled */
+	/** convierte las columnas numeric del resultado de One, Text, All y Each a Numeric (sin perder precisión) en lugar de []byte */
+	DecodeNumeric bool
+	/** decodifica las columnas json y jsonb del resultado de One, Text, All y Each a map[string]interface{} o []interface{} en lugar de []byte */
+	DecodeJSON bool
 }
 
 type Querys struct {
-	Table   string   /** nombre de la tabla*/
-	query   sintaxis /** guarda la estructura sql  de la consulta que se va contrayendo para luego ser formateada y mostrada en un string */
-	rowSql  *sql.Rows
-	colSql  []string
-	db      *sql.DB
-	tx      *sql.Tx
-	ctx     context.Context
-	err     error
-	argsLen int           /** lleva en cuenta la cantidad de argumentos que tiene la consulta*/
-	args    []interface{} /** almacena los argumentos que se le esta pasando ala consulta, el len de esta variable debe de ser igual al argsLen */
+	Table      string   /** nombre de la tabla*/
+	query      sintaxis /** guarda la estructura sql  de la consulta que se va contrayendo para luego ser formateada y mostrada en un string */
+	rowSql     *sql.Rows
+	colSql     []string
+	unmatched  []string /** columnas del resultado sin campo en el struct destino de Scan */
+	db         *sql.DB
+	tx         *sql.Tx
+	ctx        context.Context
+	cancel     context.CancelFunc /** libera el contexto con tiempo limite de la consulta al cerrar el resultado */
+	release    func()             /** libera la sentencia preparada de la cache al cerrar el resultado */
+	stmts      *stmtCache         /** cache de sentencias preparadas del pool de la transacción abierta con Connect */
+	decode     decodeOptions      /** conversiones de las columnas del resultado establecidas en QConfig */
+	decoders   []columnDecoder    /** conversión de cada columna del resultado de la ultima consulta */
+	err        error
+	execErr    bool          /** err fue producido por la ultima ejecución y se descarta al volver a ejecutar, los errores del constructor se conservan */
+	safe       bool          /** modo seguro de identificadores, ver SafeIdentifiers */
+	argsLen    int           /** lleva en cuenta la cantidad de argumentos que tiene la consulta*/
+	args       []interface{} /** almacena los argumentos que se le esta pasando ala consulta, el len de esta variable debe de ser igual al argsLen */
+	fromArgs   int           /** cantidad de argumentos de las subconsultas del JOIN, se conservan al reiniciar la cláusula WHERE */
+	havingArgs int           /** cantidad de argumentos antes de la cláusula HAVING, se conservan al reiniciar la cláusula HAVING */
 }
 
 /** guarda la estructura de consulta sql, aparir de aquí se generar la consulta sql */
 type sintaxis struct {
+	With          []string /** expresiones de tabla común (nombre AS (subconsulta)) */
+	recursive     bool     /** genera la cláusula WITH RECURSIVE */
 	Select        string
 	Where         string
 	Join          []string
 	Top           string
 	OrderBy       string
 	GroupBy       string
-	queryFull     string /** guarda la consulta sql directa en string */
-	workQueryFull bool   /** establece si se va a utilizar una consulta directa mediante queryFull o mediante la estructura true:= se considerara queryFull false:= se considerara  estructura para formar la consulta sql*/
+	Having        string
+	from          string     /** tabla de la cláusula FROM establecida en Select */
+	Compound      []compound /** consultas combinadas con UNION, INTERSECT o EXCEPT */
+	queryFull     string     /** guarda la consulta sql directa en string */
+	workQueryFull bool       /** establece si se va a utilizar una consulta directa mediante queryFull o mediante la estructura true:= se considerara queryFull false:= se considerara  estructura para formar la consulta sql*/
 }
 
 /** operaciones utilizadas con la sentencia WHERE*/
@@ -55,6 +80,21 @@ const (
 	NOT_IN      OperatorWhere = "NOT IN"
 	BETWEEN     OperatorWhere = "BETWEEN"
 	NOT_BETWEEN OperatorWhere = "NOT BETWEEN"
+	IS_NULL     OperatorWhere = "IS NULL"     /** no utiliza argumento, se puede enviar nil */
+	IS_NOT_NULL OperatorWhere = "IS NOT NULL" /** no utiliza argumento, se puede enviar nil */
+	ILIKE       OperatorWhere = "ILIKE"
+	NOT_LIKE    OperatorWhere = "NOT LIKE"
+	NOT_ILIKE   OperatorWhere = "NOT ILIKE"
+	REGEX       OperatorWhere = "~"          /** expresión regular distinguiendo mayúsculas */
+	IREGEX      OperatorWhere = "~*"         /** expresión regular sin distinguir mayúsculas */
+	NOT_REGEX   OperatorWhere = "!~"         /** no cumple la expresión regular distinguiendo mayúsculas */
+	NOT_IREGEX  OperatorWhere = "!~*"        /** no cumple la expresión regular sin distinguir mayúsculas */
+	ANY         OperatorWhere = "= ANY"      /** el argumento debe de ser un slice, se envía como un solo arreglo: campo = ANY($1) */
+	CONTAINS    OperatorWhere = "@>"         /** arreglo o jsonb contiene: slice => arreglo, map o struct => jsonb */
+	CONTAINED   OperatorWhere = "<@"         /** arreglo o jsonb contenido en: slice => arreglo, map o struct => jsonb */
+	OVERLAP     OperatorWhere = "&&"         /** arreglos con elementos en común, el argumento debe de ser un slice */
+	EXISTS      OperatorWhere = "EXISTS"     /** el argumento debe de ser una subconsulta (*Querys), el campo se ignora */
+	NOT_EXISTS  OperatorWhere = "NOT EXISTS" /** el argumento debe de ser una subconsulta (*Querys), el campo se ignora */
 )
 
 /** Tipos de Join a utilizar en la consulta*/
@@ -69,28 +109,36 @@ const (
 )
 
 func (q *Querys) Connect(config QConfig) *Querys {
-	cloud := config.Cloud
+	return q.ConnectContext(context.Background(), config)
+}
+
+/*
+*
+ConnectContext abre una transacción asociada al contexto recibido, las consultas ejecutadas con ExecTx
+utilizaran este contexto, si el contexto se cancela la transacción se revierte.
+
+Parámetros:
+  - ctx: contexto de la transacción.
+  - config: Configuración para la conexión a la base de datos.
+
+Devuelve:
+  - Un puntero al struct Querys con la transacción abierta.
+*/
+func (q *Querys) ConnectContext(ctx context.Context, config QConfig) *Querys {
 	var errs error
-	if cloud {
-		q.db, errs = ConnectionCloud()
-		if errs != nil {
-			q.err = errs
-			fmt.Println("Error SQL:", errs.Error())
-			return q
-		}
-	} else {
-		q.db, errs = Connection(config.Database)
-		if errs != nil {
-			q.err = errs
-			fmt.Println("Error SQL:", errs.Error())
-			return q
-		}
+	q.db, q.stmts, errs = getPool(ctx, config)
+	if errs != nil {
+		q.err, q.execErr = errs, false
+		fmt.Println("Error SQL:", errs.Error())
+		return q
 	}
-	q.ctx = context.Background()
+	q.ctx = ctx
+	q.decode = newDecodeOptions(config)
 	q.tx, errs = q.db.BeginTx(q.ctx, nil)
+	errs = contextError(ctx, errs)
 
 	if errs != nil {
-		q.err = errs
+		q.err, q.execErr = errs, false
 		fmt.Println("Error SQL:", errs.Error())
 	}
 
@@ -149,10 +197,21 @@ Devuelve:
   - Un puntero al struct Querys actualizado para permitir el encadenamiento de métodos.
 */
 func (q *Querys) Select(campos ...string) *Querys {
+	table := q.Table
+	if q.safe {
+		var err error
+		if table, err = quoteAliased(q.Table); err != nil {
+			return q.setError(err)
+		}
+		if campos, err = quoteColumns(campos); err != nil {
+			return q.setError(err)
+		}
+	}
+	q.query.from = table
 	if len(campos) == 0 {
-		q.query.Select = "SELECT * FROM " + q.Table
+		q.query.Select = "SELECT * FROM " + table
 	} else {
-		q.query.Select = "SELECT " + strings.Join(campos, ",") + " FROM " + q.Table
+		q.query.Select = "SELECT " + strings.Join(campos, ",") + " FROM " + table
 	}
 
 	return q
@@ -180,6 +239,15 @@ Devuelve:
   - Un puntero al struct Querys actualizado para permitir el encadenamiento de métodos.
 */
 func (q *Querys) Join(tp TypeJoin, table string, on string) *Querys {
+	if q.safe {
+		var err error
+		if table, err = quoteAliased(table); err != nil {
+			return q.setError(err)
+		}
+		if on, err = quoteOn(on); err != nil {
+			return q.setError(err)
+		}
+	}
 	q.query.Join = append(q.query.Join, fmt.Sprintf(" %s  %s ON %s", tp, table, on))
 	return q
 }
@@ -197,23 +265,29 @@ Ejemplo de uso:
 	result,err:=queryBuilder.Select("campo1, campo2").Where("campo3", "=", valor).Exec("mi_database").All()
 	consultaFinal := queryBuilder.GetQuery()
 
+El argumento también puede ser una subconsulta (*Querys) con los operadores IN, NOT IN, EXISTS y NOT EXISTS,
+sus argumentos se agregan a la consulta renumerando los placeholders:
+
+	ventas := &Querys{Table: "stock_ventas"}
+	ventas.Select("n_docu").Where("n_year", I, 2024)
+	queryBuilder.Select().Where("c_ubig", I, "120119").And("n_docu", IN, ventas)
+	// SELECT * FROM mi_tabla WHERE c_ubig = $1 AND n_docu IN (SELECT n_docu FROM stock_ventas WHERE n_year = $2)
+
 Parámetros:
   - where: Condición para la cláusula WHERE.
-  - op: Operador para comparar valores en la condición (por ejemplo, "=", "<>", ">", "<","<=", ">=", "LIKE", "IN", "NOT IN", "BETWEEN" "NOT BETWEEN").
+  - op: Operador para comparar valores en la condición (por ejemplo, "=", "<>", ">", "<","<=", ">=", "LIKE", "IN", "NOT IN", "BETWEEN" "NOT BETWEEN", "IS NULL", "ILIKE", "~", "= ANY", "@>", "&&", "EXISTS", etc.).
   - arg: Valor que se compara en la condición.
 
 Devuelve:
   - Un puntero al struct Querys actualizado para permitir el encadenamiento de métodos.
 */
 func (q *Querys) Where(where string, op OperatorWhere, arg interface{}) *Querys {
-	q.argsLen = 1
-	q.args = []interface{}{}
-	argString, err := getSintaxisFilter(q, op, arg)
+	q.resetWhere()
+	condition, err := getCondition(q, where, op, arg)
 	if err != nil {
-		fmt.Println(err)
-		return q
+		return q.setError(err)
 	}
-	q.query.Where = fmt.Sprintf(" WHERE %s %s %s", where, op, argString)
+	q.query.Where = fmt.Sprintf(" WHERE %s", condition)
 	return q
 }
 
@@ -233,7 +307,7 @@ Ejemplo de uso:
 
 Parámetros:
   - and: Condición adicional para agregar a la cláusula WHERE existente.
-  - op: Operador para comparar valores en la condición (por ejemplo, "=", "<>", ">", "<","<=", ">=", "LIKE", "IN", "NOT IN", "BETWEEN" "NOT BETWEEN").
+  - op: Operador para comparar valores en la condición (por ejemplo, "=", "<>", ">", "<","<=", ">=", "LIKE", "IN", "NOT IN", "BETWEEN" "NOT BETWEEN", "IS NULL", "ILIKE", "~", "= ANY", "@>", "&&", "EXISTS", etc.).
   - arg: Valor que se compara en la condición.
 
 Devuelve:
@@ -243,12 +317,11 @@ func (q *Querys) And(and string, op OperatorWhere, arg interface{}) *Querys {
 	if q.query.Where == "" {
 		return q
 	}
-	argString, err := getSintaxisFilter(q, op, arg)
+	condition, err := getCondition(q, and, op, arg)
 	if err != nil {
-		fmt.Println(err)
-		return q
+		return q.setError(err)
 	}
-	q.query.Where += fmt.Sprintf(" AND %s %s %s", and, op, argString)
+	q.query.Where += fmt.Sprintf(" AND %s", condition)
 	return q
 }
 
@@ -268,7 +341,7 @@ Ejemplo de uso:
 
 Parámetros:
   - and: Condición adicional para agregar a la cláusula WHERE existente.
-  - op: Operador para comparar valores en la condición (por ejemplo, "=", "<>", ">", "<","<=", ">=", "LIKE", "IN", "NOT IN", "BETWEEN" "NOT BETWEEN").
+  - op: Operador para comparar valores en la condición (por ejemplo, "=", "<>", ">", "<","<=", ">=", "LIKE", "IN", "NOT IN", "BETWEEN" "NOT BETWEEN", "IS NULL", "ILIKE", "~", "= ANY", "@>", "&&", "EXISTS", etc.).
   - arg: Valor que se compara en la condición.
 
 Devuelve:
@@ -278,12 +351,11 @@ func (q *Querys) Or(or string, op OperatorWhere, arg interface{}) *Querys {
 	if q.query.Where == "" {
 		return q
 	}
-	argString, err := getSintaxisFilter(q, op, arg)
+	condition, err := getCondition(q, or, op, arg)
 	if err != nil {
-		fmt.Println(err)
-		return q
+		return q.setError(err)
 	}
-	q.query.Where += fmt.Sprintf(" OR %s %s %s", or, op, argString)
+	q.query.Where += fmt.Sprintf(" OR %s", condition)
 	return q
 }
 
@@ -305,6 +377,12 @@ Devuelve:
   - Un puntero al struct Querys actualizado para permitir el encadenamiento de métodos.
 */
 func (q *Querys) OrderBy(campos ...string) *Querys {
+	if q.safe {
+		var err error
+		if campos, err = quoteOrderBy(campos); err != nil {
+			return q.setError(err)
+		}
+	}
 	q.query.OrderBy = " ORDER BY " + strings.Join(campos, ",")
 	return q
 }
@@ -330,10 +408,102 @@ func (q *Querys) GroupBy(group ...string) *Querys {
 	if len(group) <= 0 {
 		return q
 	}
+	if q.safe {
+		var err error
+		if group, err = quoteColumns(group); err != nil {
+			return q.setError(err)
+		}
+	}
 	q.query.GroupBy = fmt.Sprintf(" GROUP BY %s", strings.Join(group, ","))
 	return q
 }
 
+/*
+*
+Having establece la cláusula HAVING de la consulta SQL, utiliza los mismos operadores y la misma numeración
+de argumentos que Where. Debe de llamarse después de Where y GroupBy.
+
+Ejemplo de uso:
+
+	queryBuilder := &Querys{Table: "stock_ventasDetalle"}
+	result,err:=queryBuilder.Select("c_prod", "sum(s_cant) as s_cant").Where("n_year", I, 2024).GroupBy("c_prod").Having("sum(s_cant)", MY, 100).Exec(QConfig{Database: "mi_database"}).All()
+	// SELECT c_prod,sum(s_cant) as s_cant FROM stock_ventasDetalle WHERE n_year = $1 GROUP BY c_prod HAVING sum(s_cant) > $2
+
+Parámetros:
+  - having: campo o función de agregado a comparar.
+  - op: Operador para comparar valores en la condición.
+  - arg: Valor que se compara en la condición.
+
+Devuelve:
+  - Un puntero al struct Querys actualizado para permitir el encadenamiento de métodos.
+*/
+func (q *Querys) Having(having string, op OperatorWhere, arg interface{}) *Querys {
+	if q.query.Having != "" {
+		/** descarta los argumentos de la cláusula HAVING anterior */
+		q.args = q.args[:q.havingArgs]
+		q.argsLen = q.havingArgs + 1
+		q.query.Having = ""
+	} else {
+		q.havingArgs = len(q.args)
+	}
+	condition, err := getCondition(q, having, op, arg)
+	if err != nil {
+		return q.setError(err)
+	}
+	q.query.Having = fmt.Sprintf(" HAVING %s", condition)
+	return q
+}
+
+/*
+*
+AndHaving añade una condición AND a la cláusula HAVING existente.
+Si la cláusula HAVING aún no está especificada en la consulta, esta función no hace nada.
+
+Parámetros:
+  - and: campo o función de agregado a comparar.
+  - op: Operador para comparar valores en la condición.
+  - arg: Valor que se compara en la condición.
+
+Devuelve:
+  - Un puntero al struct Querys actualizado para permitir el encadenamiento de métodos.
+*/
+func (q *Querys) AndHaving(and string, op OperatorWhere, arg interface{}) *Querys {
+	if q.query.Having == "" {
+		return q
+	}
+	condition, err := getCondition(q, and, op, arg)
+	if err != nil {
+		return q.setError(err)
+	}
+	q.query.Having += fmt.Sprintf(" AND %s", condition)
+	return q
+}
+
+/*
+*
+OrHaving añade una condición OR a la cláusula HAVING existente.
+Si la cláusula HAVING aún no está especificada en la consulta, esta función no hace nada.
+
+Parámetros:
+  - or: campo o función de agregado a comparar.
+  - op: Operador para comparar valores en la condición.
+  - arg: Valor que se compara en la condición.
+
+Devuelve:
+  - Un puntero al struct Querys actualizado para permitir el encadenamiento de métodos.
+*/
+func (q *Querys) OrHaving(or string, op OperatorWhere, arg interface{}) *Querys {
+	if q.query.Having == "" {
+		return q
+	}
+	condition, err := getCondition(q, or, op, arg)
+	if err != nil {
+		return q.setError(err)
+	}
+	q.query.Having += fmt.Sprintf(" OR %s", condition)
+	return q
+}
+
 /*
 *
 Top establece la cláusula LIMIT de la consulta SQL para seleccionar un número específico de filas.
@@ -405,42 +575,46 @@ Devuelve:
   - Un puntero al struct Querys actualizado con los resultados de la consulta ejecutada.
 */
 func (q *Querys) Exec(config QConfig) *Querys {
-	cloud := config.Cloud
-	var db *sql.DB
-	if cloud {
-		var errs error
-		db, errs = ConnectionCloud()
-		if errs != nil {
-			q.err = errs
-			fmt.Println("Error SQL:", errs.Error())
-			return q
-		}
+	return q.ExecContext(context.Background(), config)
+}
 
-	} else {
-		var errs error
-		db, errs = Connection(config.Database)
-		if errs != nil {
-			q.err = errs
-			fmt.Println("Error SQL:", errs.Error())
-			return q
-		}
+/*
+*
+ExecContext ejecuta la consulta SQL al igual que Exec propagando la cancelación y el tiempo limite del contexto
+hasta PostgreSQL, si la consulta es cancelada el error obtenido contiene ErrQueryCanceled.
 
-	}
+Ejemplo de uso:
 
-	ctx := context.Background()
-	err := db.PingContext(ctx)
-	defer db.Close()
+	queryBuilder := &Querys{Table: "mi_tabla"}
+	result,err:=queryBuilder.Select().Where("campo3", "=", valor).ExecContext(r.Context(), QConfig{Database: "mi_database", Timeout: 5 * time.Second}).All()
+
+Parámetros:
+  - ctx: contexto de la consulta.
+  - config: Configuración para la conexión a la base de datos.
+
+Devuelve:
+  - Un puntero al struct Querys actualizado con los resultados de la consulta ejecutada.
+*/
+func (q *Querys) ExecContext(ctx context.Context, config QConfig) *Querys {
+	if !q.beforeExec() {
+		return q
+	}
+	ctx, cancel := withTimeout(ctx, config.Timeout)
+	db, cache, err := getPool(ctx, config)
 	if err != nil {
-		q.err = err
-		fmt.Println("Error SQL ping:", err.Error())
+		cancel()
+		q.setExecError(err)
+		fmt.Println("Error SQL:", err.Error())
 		return q
 	}
+
 	queryString := q.GetQuery()
 	// fmt.Println("query:", queryString)
 	if !config.Procedure {
-		rows, err := db.QueryContext(ctx, queryString, q.args...)
+		rows, release, err := queryCached(ctx, cache, db, nil, queryString, q.getArgs())
 		if err != nil {
-			q.err = err
+			cancel()
+			q.setExecError(contextError(ctx, err))
 			fmt.Println("Error SQL exec:", err.Error())
 			return q
 		}
@@ -449,12 +623,17 @@ func (q *Querys) Exec(config QConfig) *Querys {
 
 		q.rowSql = rows
 		q.colSql = cols
+		q.cancel = cancel
+		q.release = release
+		q.decode = newDecodeOptions(config)
+		q.decoders = columnDecoders(rows, q.decode)
 
 		return q
 	} else {
-		_, err = db.ExecContext(ctx, queryString, q.args...)
+		defer cancel()
+		_, err = db.ExecContext(ctx, queryString, q.getArgs()...)
 		if err != nil {
-			q.err = err
+			q.setExecError(contextError(ctx, err))
 			fmt.Println("Error SQL exec:", err.Error())
 		}
 		return q
@@ -463,36 +642,79 @@ func (q *Querys) Exec(config QConfig) *Querys {
 }
 
 func (q *Querys) ExecTx() *Querys {
-	if q.err != nil {
-		return q
+	ctx := q.ctx
+	if ctx == nil {
+		ctx = context.Background()
 	}
+	return q.ExecTxContext(ctx)
+}
 
-	err := q.db.PingContext(q.ctx)
-	// defer q.db.Close()
-	if err != nil {
-		q.err = err
-		fmt.Println("Error SQL ping:", err.Error())
+/*
+*
+ExecTxContext ejecuta la consulta dentro de la transacción abierta con Connect utilizando el contexto recibido.
+
+Parámetros:
+  - ctx: contexto de la consulta.
+
+Devuelve:
+  - Un puntero al struct Querys actualizado con los resultados de la consulta ejecutada.
+*/
+func (q *Querys) ExecTxContext(ctx context.Context) *Querys {
+	if !q.beforeExec() {
 		return q
 	}
 
-	ctx := context.Background()
 	queryString := q.GetQuery()
-	rows, err := q.tx.QueryContext(ctx, queryString, q.args...)
+	rows, release, err := queryCached(ctx, q.stmts, q.db, q.tx, queryString, q.getArgs())
 	if err != nil {
 		fmt.Println("Error SQL exec tx:", err.Error())
-		q.err = err
+		q.setExecError(contextError(ctx, err))
 		return q
 	}
 	cols, _ := rows.Columns()
 
 	q.rowSql = rows
 	q.colSql = cols
+	q.release = release
+	q.decoders = columnDecoders(rows, q.decode)
 	return q
 }
 
+/*
+beforeExec cierra el resultado anterior que no se termino de leer y descarta el error de la ejecución anterior,
+retorna false si la consulta tiene un error del constructor (por ejemplo un identificador rechazado) y no se debe de ejecutar.
+*/
+func (q *Querys) beforeExec() bool {
+	q.closeRows()
+	if q.err != nil && !q.execErr {
+		return false
+	}
+	q.err, q.execErr = nil, false
+	return true
+}
+
+/** registra el error de la ejecución de la consulta */
+func (q *Querys) setExecError(err error) {
+	q.err, q.execErr = err, true
+}
+
+/** reinicia los argumentos de la consulta antes de establecer una nueva cláusula WHERE, conserva los argumentos de los JOIN */
+func (q *Querys) resetWhere() {
+	if q.query.Having != "" {
+		q.setError(errors.New("Where debe de llamarse antes de Having"))
+	}
+	if q.fromArgs > len(q.args) {
+		q.fromArgs = len(q.args)
+	}
+	q.argsLen = q.fromArgs + 1
+	q.args = append([]interface{}{}, q.args[:q.fromArgs]...)
+}
+
 func (q *Querys) ResetQuery() {
 	q.query = sintaxis{}
 	q.argsLen = 0
+	q.fromArgs = 0
+	q.havingArgs = 0
 	q.args = []interface{}{}
 }
 
@@ -505,10 +727,31 @@ func (q *Querys) SetNewArgs(arg ...interface{}) error {
 	return nil
 }
 
+/*
+Close confirma la transacción abierta con Connect y libera el resultado de la ultima consulta.
+
+La conexión regresa al pool compartido, para cerrar las conexiones se debe de utilizar ClosePools.
+*/
 func (q *Querys) Close() {
-	q.tx.Commit()
-	q.rowSql.Close()
-	q.db.Close()
+	if q.tx != nil {
+		q.tx.Commit()
+	}
+	q.closeRows()
+}
+
+/** cierra el resultado de la consulta y libera su contexto */
+func (q *Querys) closeRows() {
+	if q.rowSql != nil {
+		q.rowSql.Close()
+	}
+	if q.release != nil {
+		q.release()
+		q.release = nil
+	}
+	if q.cancel != nil {
+		q.cancel()
+		q.cancel = nil
+	}
 }
 
 /*
@@ -531,6 +774,7 @@ Devuelve:
 */
 func (q *Querys) One() (map[string]interface{}, error) {
 	m := make(map[string]interface{})
+	defer q.closeRows()
 	if q.err != nil {
 		return m, q.err
 	}
@@ -553,10 +797,14 @@ func (q *Querys) One() (map[string]interface{}, error) {
 				m[colName] = l
 			}
 		}
+		if err := decodeRow(m, q.colSql, q.decoders); err != nil {
+			return map[string]interface{}{}, err
+		}
 		break
 	}
-
-	defer q.rowSql.Close()
+	if err := q.rowSql.Err(); err != nil {
+		return map[string]interface{}{}, canceledError(err)
+	}
 	return m, nil
 }
 
@@ -582,6 +830,7 @@ Devuelve:
 */
 func (q *Querys) Text(columna string) (interface{}, error) {
 	m := make(map[string]interface{})
+	defer q.closeRows()
 	if q.err != nil {
 		return nil, q.err
 	}
@@ -608,10 +857,15 @@ func (q *Querys) Text(columna string) (interface{}, error) {
 			}
 
 		}
+		if err := decodeRow(m, q.colSql, q.decoders); err != nil {
+			return nil, err
+		}
 
 		break
 	}
-	defer q.rowSql.Close()
+	if err := q.rowSql.Err(); err != nil {
+		return nil, canceledError(err)
+	}
 	return m[columna], nil
 }
 
@@ -634,6 +888,7 @@ Devuelve:
 */
 func (q *Querys) All() ([]map[string]interface{}, error) {
 	result := make([]map[string]interface{}, 0)
+	defer q.closeRows()
 	if q.err != nil {
 		return result, q.err
 	}
@@ -668,10 +923,16 @@ func (q *Querys) All() ([]map[string]interface{}, error) {
 			}
 		}
 
+		if err := decodeRow(m, q.colSql, q.decoders); err != nil {
+			return []map[string]interface{}{}, err
+		}
+
 		// Outputs: map[columnName:value columnName2:value2 columnName3:value3 ...]
 		result = append(result, m)
 	}
-	defer q.rowSql.Close()
+	if err := q.rowSql.Err(); err != nil {
+		return []map[string]interface{}{}, canceledError(err)
+	}
 	return result, nil
 }
 
@@ -680,7 +941,7 @@ func (q *Querys) All() ([]map[string]interface{}, error) {
 GetQuery devuelve la consulta SQL completa construida utilizando los métodos del struct Querys.
 
 Esta función se utiliza para obtener la consulta SQL completa que se ha construido utilizando
-los métodos del struct Querys, incluyendo la selección de columnas, cláusulas JOIN, condiciones WHERE,
+los métodos del struct Querys, incluyendo las expresiones WITH, la selección de columnas, cláusulas JOIN, condiciones WHERE, consultas combinadas (UNION, INTERSECT, EXCEPT),
 agrupamiento GROUP BY, ordenamiento ORDER BY, y limitación de resultados con TOP o LIMIT.
 
 Devuelve:
@@ -689,18 +950,7 @@ Devuelve:
 func (q *Querys) GetQuery() string {
 	var queryString string
 	if !q.query.workQueryFull {
-		queryString = q.query.Select
-		/** aplicando los join  inner join, left join y right join*/
-		if len(q.query.Join) > 0 {
-			for _, v := range q.query.Join {
-				queryString += v
-			}
-		}
-		/** aplicando Where : where ,and ,or ,in, between ,not in ,not between*/
-		queryString += q.query.Where
-
-		/** aplicando Group by*/
-		queryString += q.query.GroupBy
+		queryString = q.getWith() + q.getCompoundBody()
 
 		/** aplicando order by  */
 		queryString += q.query.OrderBy
@@ -713,6 +963,64 @@ func (q *Querys) GetQuery() string {
 	return queryString
 }
 
+/** retorna la consulta sin ORDER BY ni LIMIT, utilizada como base para los conteos */
+func (q *Querys) getQueryBody() string {
+	if q.query.Select == "" {
+		q.Select()
+	}
+	queryString := q.query.Select
+	/** aplicando los join  inner join, left join y right join*/
+	if len(q.query.Join) > 0 {
+		for _, v := range q.query.Join {
+			queryString += v
+		}
+	}
+	/** aplicando Where : where ,and ,or ,in, between ,not in ,not between*/
+	queryString += q.query.Where
+
+	/** aplicando Group by*/
+	queryString += q.query.GroupBy
+	queryString += q.query.Having
+	return queryString
+}
+
+/** retorna la consulta que cuenta las filas de la consulta construida ignorando ORDER BY y LIMIT */
+func (q *Querys) getCountQuery() string {
+	if q.query.workQueryFull {
+		return fmt.Sprintf("SELECT count(*) FROM (%s) AS basicgorm_count", q.query.queryFull)
+	}
+	return fmt.Sprintf("%sSELECT count(*) FROM (%s) AS basicgorm_count", q.getWith(), q.getCompoundBody())
+}
+
+/** ejecuta una consulta que retorna un solo valor y lo almacena en dest */
+func (q *Querys) queryScalar(ctx context.Context, config QConfig, query string, dest interface{}) error {
+	if q.err != nil {
+		return q.err
+	}
+	ctx, cancel := withTimeout(ctx, config.Timeout)
+	defer cancel()
+	db, cache, err := getPool(ctx, config)
+	if err != nil {
+		return err
+	}
+	rows, release, err := queryCached(ctx, cache, db, nil, query, q.getArgs())
+	if err != nil {
+		return contextError(ctx, err)
+	}
+	defer release()
+	defer rows.Close()
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return contextError(ctx, err)
+		}
+		return sql.ErrNoRows
+	}
+	if err := rows.Scan(dest); err != nil {
+		return contextError(ctx, err)
+	}
+	return contextError(ctx, rows.Close())
+}
+
 func (q *Querys) GetErrors() error {
 
 	return q.err
@@ -724,6 +1032,7 @@ getSintaxisFilter genera la sintaxis adecuada para los filtros de las consultas
 
 Esta función se utiliza para generar la sintaxis adecuada para los filtros de las consultas SQL, como IN, NOT IN, BETWEEN, y NOT BETWEEN,
 y maneja los argumentos correspondientes, agregándolos a la lista de argumentos de la consulta SQL.
+Si el argumento es una subconsulta (*Querys) se agrega entre paréntesis junto con sus argumentos (IN, NOT IN, EXISTS y NOT EXISTS).
 
 Parámetros:
   - q: Una instancia del struct Querys que contiene información sobre los argumentos de la consulta.
@@ -737,6 +1046,16 @@ Devuelve:
 func getSintaxisFilter(q *Querys, op OperatorWhere, arg interface{}) (string, error) {
 	var argString string
 
+	if sub, ok := arg.(*Querys); ok {
+		if op != IN && op != NOT_IN && op != EXISTS && op != NOT_EXISTS {
+			return "", fmt.Errorf("subconsulta no permitida para el filtrado %s", op)
+		}
+		return q.bindQuery(sub)
+	}
+	if op == EXISTS || op == NOT_EXISTS {
+		return "", fmt.Errorf("se esperaba una subconsulta para el filtrado %s", op)
+	}
+
 	if op == IN || op == NOT_IN {
 		if reflect.TypeOf(arg).String() != "[]interface {}" {
 			return "", errors.New("tipo de dato incorrecto para filtrado IN")
@@ -746,9 +1065,7 @@ func getSintaxisFilter(q *Querys, op OperatorWhere, arg interface{}) (string, er
 		}
 		arrayArgsSql := make([]string, 0)
 		for _, v := range arg.([]interface{}) {
-			arrayArgsSql = append(arrayArgsSql, fmt.Sprintf("$%d", q.argsLen))
-			q.args = append(q.args, v)
-			q.argsLen++
+			arrayArgsSql = append(arrayArgsSql, q.bind(v))
 		}
 		argString = fmt.Sprintf("(%s)", strings.Join(arrayArgsSql, ","))
 	} else if op == BETWEEN || op == NOT_BETWEEN {
@@ -758,17 +1075,91 @@ func getSintaxisFilter(q *Querys, op OperatorWhere, arg interface{}) (string, er
 		if len(arg.([]interface{})) < 2 {
 			return "", errors.New("valor vació o bien valores incompletos para filtrado BETWEEN")
 		}
-		argString = fmt.Sprintf("$%d AND ", q.argsLen)
-		q.args = append(q.args, arg.([]interface{})[0])
-		q.argsLen++
-		argString += fmt.Sprintf("$%d", q.argsLen)
-		q.args = append(q.args, arg.([]interface{})[1])
-		q.argsLen++
+		argString = q.bind(arg.([]interface{})[0]) + " AND "
+		argString += q.bind(arg.([]interface{})[1])
+	} else if op == IS_NULL || op == IS_NOT_NULL {
+		/** no requiere placeholder ni argumento */
+		argString = ""
+	} else if op == ANY || op == OVERLAP {
+		if !isArrayArg(arg) {
+			return "", fmt.Errorf("tipo de dato incorrecto para filtrado %s, se esperaba un slice", op)
+		}
+		argString = q.bind(pq.Array(arg))
+		if op == ANY {
+			argString = fmt.Sprintf("(%s)", argString)
+		}
+	} else if op == CONTAINS || op == CONTAINED {
+		if isArrayArg(arg) {
+			argString = q.bind(pq.Array(arg))
+		} else if kind := reflect.Indirect(reflect.ValueOf(arg)).Kind(); kind == reflect.Map || kind == reflect.Struct {
+			jsonArg, err := json.Marshal(arg)
+			if err != nil {
+				return "", fmt.Errorf("error al convertir el filtrado %s a json: %s", op, err.Error())
+			}
+			argString = q.bind(string(jsonArg)) + "::jsonb"
+		} else {
+			argString = q.bind(arg)
+		}
 	} else {
-		argString = fmt.Sprintf("$%d", q.argsLen)
-		q.args = append(q.args, arg)
-		q.argsLen++
+		argString = q.bind(arg)
 	}
 
 	return argString, nil
 }
+
+/** indica si el argumento es un slice o arreglo que se debe enviar como arreglo de PostgreSQL ([]byte no se considera arreglo) */
+func isArrayArg(arg interface{}) bool {
+	if arg == nil {
+		return false
+	}
+	t := reflect.TypeOf(arg)
+	return (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && t.Elem().Kind() != reflect.Uint8
+}
+
+/*
+getCondition genera la condición completa (campo, operador y placeholders) agregando los argumentos a la consulta.
+
+Parámetros:
+  - q: Una instancia del struct Querys donde se agregan los argumentos.
+  - column: El campo o expresión a comparar.
+  - op: El operador de filtro (OperatorWhere).
+  - arg: El valor o valores del filtro.
+
+Devuelve:
+  - Una cadena con la condición, por ejemplo "campo = $1".
+  - Un error, si ocurre alguno durante el proceso de generación de la sintaxis.
+*/
+func getCondition(q *Querys, column string, op OperatorWhere, arg interface{}) (string, error) {
+	if op == EXISTS || op == NOT_EXISTS {
+		argString, err := getSintaxisFilter(q, op, arg)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s %s", op, argString), nil
+	}
+	if q.safe {
+		var err error
+		if column, err = quoteAggregate(column); err != nil {
+			return "", err
+		}
+	}
+	argString, err := getSintaxisFilter(q, op, arg)
+	if err != nil {
+		return "", err
+	}
+	if argString == "" {
+		return fmt.Sprintf("%s %s", column, op), nil
+	}
+	return fmt.Sprintf("%s %s %s", column, op, argString), nil
+}
+
+/** agrega el argumento a la consulta y retorna su placeholder ($n) */
+func (q *Querys) bind(arg interface{}) string {
+	if q.argsLen == 0 {
+		q.argsLen = len(q.args) + 1
+	}
+	placeholder := fmt.Sprintf("$%d", q.argsLen)
+	q.args = append(q.args, arg)
+	q.argsLen++
+	return placeholder
+}
@@ -0,0 +1,233 @@
+package basicgorm
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+/** genera la cláusula RETURNING validando las columnas, vació si no se solicitaron columnas */
+func returningClause(returning []string) (string, error) {
+	if len(returning) == 0 {
+		return "", nil
+	}
+	for _, column := range returning {
+		if err := CheckIdentifier(column); err != nil {
+			return "", err
+		}
+	}
+	return " RETURNING " + strings.Join(returning, ", "), nil
+}
+
+/** indica si la sentencia fue generada con la cláusula RETURNING */
+func hasReturning(item map[string]interface{}) bool {
+	returning, _ := item["returning"].(bool)
+	return returning
+}
+
+/*
+itemCache retorna la cache de sentencias preparadas a utilizar con la sentencia, nil para los INSERT de varias filas:
+su texto cambia con la cantidad de registros y reutilizarlos desplazaría de la cache a las sentencias frecuentes.
+*/
+func itemCache(cache *stmtCache, item map[string]interface{}) *stmtCache {
+	if records, _ := item["records"].(int); records > 1 {
+		return nil
+	}
+	return cache
+}
+
+/** ejecuta la sentencia dentro de la transacción utilizando la cache de sentencias preparadas del pool */
+func execTxItem(ctx context.Context, cache *stmtCache, db *sql.DB, tx *sql.Tx, sqlPre string, item map[string]interface{}) ([]map[string]interface{}, error) {
+	stmt, release, err := itemCache(cache, item).prepareTx(ctx, db, tx, sqlPre)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return execStmt(ctx, stmt, item)
+}
+
+/** ejecuta la sentencia preparada con los valores del item, si tiene RETURNING retorna las filas obtenidas */
+func execStmt(ctx context.Context, stmt *sql.Stmt, item map[string]interface{}) ([]map[string]interface{}, error) {
+	valuesExec := item["valuesExec"].([]interface{})
+	if !hasReturning(item) {
+		_, err := stmt.ExecContext(ctx, valuesExec...)
+		return nil, err
+	}
+	rows, err := stmt.QueryContext(ctx, valuesExec...)
+	if err != nil {
+		return nil, err
+	}
+	return rowsToMaps(rows)
+}
+
+/** lee todas las filas del resultado como mapas columna => valor y cierra el resultado */
+func rowsToMaps(rows *sql.Rows) ([]map[string]interface{}, error) {
+	defer rows.Close()
+	result := make([]map[string]interface{}, 0)
+	cols, err := rows.Columns()
+	if err != nil {
+		return result, err
+	}
+	for rows.Next() {
+		columns := make([]interface{}, len(cols))
+		columnPointers := make([]interface{}, len(cols))
+		for i := range columns {
+			columnPointers[i] = &columns[i]
+		}
+		if err := rows.Scan(columnPointers...); err != nil {
+			return result, err
+		}
+		m := make(map[string]interface{}, len(cols))
+		for i, colName := range cols {
+			m[colName] = columns[i]
+		}
+		result = append(result, m)
+	}
+	return result, rows.Err()
+}
+
+/*
+scanReturning copia las filas retornadas por RETURNING en dest, un puntero a struct recibe la primera fila
+y un puntero a slice de structs (o de punteros a struct) recibe todas las filas de todos los registros.
+*/
+func scanReturning(returned [][]map[string]interface{}, dest interface{}) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return errors.New("se esperaba un puntero a struct o a slice de structs")
+	}
+	var rows []map[string]interface{}
+	for _, r := range returned {
+		rows = append(rows, r...)
+	}
+	if v.Elem().Kind() == reflect.Struct {
+		if len(rows) == 0 {
+			return sql.ErrNoRows
+		}
+		return assignStruct(rows[0], getStructFields(v.Elem().Type()), v.Elem())
+	}
+	if v.Elem().Kind() != reflect.Slice {
+		return errors.New("se esperaba un puntero a struct o a slice de structs")
+	}
+	slice := v.Elem()
+	elemType := slice.Type().Elem()
+	isPtr := elemType.Kind() == reflect.Ptr
+	structType := elemType
+	if isPtr {
+		structType = elemType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return errors.New("se esperaba un slice de structs")
+	}
+	fields := getStructFields(structType)
+	slice.Set(reflect.MakeSlice(slice.Type(), 0, len(rows)))
+	for _, row := range rows {
+		item := reflect.New(structType)
+		if err := assignStruct(row, fields, item.Elem()); err != nil {
+			return err
+		}
+		if isPtr {
+			slice.Set(reflect.Append(slice, item))
+		} else {
+			slice.Set(reflect.Append(slice, item.Elem()))
+		}
+	}
+	return nil
+}
+
+/** asigna los valores de la fila a los campos del struct, las columnas sin campo se descartan */
+func assignStruct(row map[string]interface{}, fields map[string][]int, v reflect.Value) error {
+	for col, value := range row {
+		index, ok := fields[strings.ToLower(col)]
+		if !ok {
+			continue
+		}
+		if err := assignValue(v.FieldByIndex(index), value); err != nil {
+			return fmt.Errorf("columna %s: %w", col, err)
+		}
+	}
+	return nil
+}
+
+/** asigna un valor leído de la base de datos al campo, convirtiendo los tipos compatibles */
+func assignValue(field reflect.Value, value interface{}) error {
+	if field.CanAddr() {
+		if scanner, ok := field.Addr().Interface().(sql.Scanner); ok {
+			return scanner.Scan(value)
+		}
+	}
+	if value == nil {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+	if field.Kind() == reflect.Ptr {
+		elem := reflect.New(field.Type().Elem())
+		if err := assignValue(elem.Elem(), value); err != nil {
+			return err
+		}
+		field.Set(elem)
+		return nil
+	}
+	if b, ok := value.([]byte); ok {
+		if field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.Uint8 {
+			field.SetBytes(append([]byte{}, b...))
+			return nil
+		}
+		value = string(b)
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Type().AssignableTo(field.Type()) {
+		field.Set(rv)
+		return nil
+	}
+	if s, ok := value.(string); ok {
+		return assignString(field, s)
+	}
+	if isNumberKind(rv.Kind()) && isNumberKind(field.Kind()) {
+		field.Set(rv.Convert(field.Type()))
+		return nil
+	}
+	return fmt.Errorf("no se puede asignar %T a %s", value, field.Type())
+}
+
+/** convierte el texto al tipo del campo, PostgreSQL retorna numeric como texto */
+func assignString(field reflect.Value, s string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	default:
+		return fmt.Errorf("no se puede asignar texto a %s", field.Type())
+	}
+	return nil
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	return (k >= reflect.Int && k <= reflect.Uint64) || k == reflect.Float32 || k == reflect.Float64
+}
@@ -0,0 +1,214 @@
+package basicgorm
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+/** cache de la relación columna => índice del campo por cada tipo de struct */
+var structFieldsCache sync.Map
+
+var (
+	scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	timeType    = reflect.TypeOf(time.Time{})
+)
+
+/*
+*
+Scan recupera los resultados de la consulta SQL en un struct o en un slice de structs según el destino.
+
+Los campos del struct se relacionan con las columnas mediante la etiqueta db (por ejemplo `db:"c_sucu"`),
+si el campo no tiene etiqueta se relaciona con la columna que tenga su mismo nombre sin distinguir mayúsculas,
+los campos con la etiqueta `db:"-"` se omiten. Los valores NULL se admiten utilizando punteros o los tipos sql.Null*.
+
+Ejemplo de uso:
+
+	type Sucursal struct {
+		Codigo    string         `db:"c_sucu"`
+		Nombre    string         `db:"l_sucu"`
+		Ubigeo    sql.NullString `db:"c_ubig"`
+		Celular   *string        `db:"n_celu"`
+	}
+	var sucursales []Sucursal
+	err := queryBuilder.Select().Exec(QConfig{Database: "mi_database"}).Scan(&sucursales)
+
+Parámetros:
+  - dest: puntero a struct (primera fila) o puntero a slice de structs o de punteros a struct (todas las filas).
+
+Devuelve:
+  - Un error, si ocurre alguno durante la lectura de los resultados.
+*/
+func (q *Querys) Scan(dest interface{}) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() == reflect.Ptr && v.Elem().Kind() == reflect.Slice {
+		return q.ScanAll(dest)
+	}
+	return q.ScanOne(dest)
+}
+
+/*
+*
+ScanOne recupera la primera fila de resultados de la consulta SQL en un struct.
+
+Parámetros:
+  - dest: puntero al struct donde se almacenara la fila.
+
+Devuelve:
+  - Un error, si ocurre alguno durante la lectura, sql.ErrNoRows si la consulta no retorno filas.
+*/
+func (q *Querys) ScanOne(dest interface{}) error {
+	defer q.closeRows()
+	if q.err != nil {
+		return q.err
+	}
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return errors.New("se esperaba un puntero a struct")
+	}
+	fields := getStructFields(v.Elem().Type())
+	q.unmatched = unmatchedColumns(q.colSql, fields)
+	if !q.rowSql.Next() {
+		if err := q.rowSql.Err(); err != nil {
+			return canceledError(err)
+		}
+		return sql.ErrNoRows
+	}
+	return scanStruct(q.rowSql, q.colSql, fields, v.Elem())
+}
+
+/*
+*
+ScanAll recupera todas las filas de resultados de la consulta SQL en un slice de structs.
+
+Parámetros:
+  - dest: puntero a un slice de structs o de punteros a struct, el contenido previo del slice se reemplaza.
+
+Devuelve:
+  - Un error, si ocurre alguno durante la lectura de los resultados.
+*/
+func (q *Querys) ScanAll(dest interface{}) error {
+	defer q.closeRows()
+	if q.err != nil {
+		return q.err
+	}
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return errors.New("se esperaba un puntero a slice")
+	}
+	slice := v.Elem()
+	elemType := slice.Type().Elem()
+	isPtr := elemType.Kind() == reflect.Ptr
+	structType := elemType
+	if isPtr {
+		structType = elemType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return errors.New("se esperaba un slice de structs")
+	}
+	fields := getStructFields(structType)
+	q.unmatched = unmatchedColumns(q.colSql, fields)
+
+	slice.Set(reflect.MakeSlice(slice.Type(), 0, 0))
+	for q.rowSql.Next() {
+		item := reflect.New(structType)
+		if err := scanStruct(q.rowSql, q.colSql, fields, item.Elem()); err != nil {
+			return err
+		}
+		if isPtr {
+			slice.Set(reflect.Append(slice, item))
+		} else {
+			slice.Set(reflect.Append(slice, item.Elem()))
+		}
+	}
+	return canceledError(q.rowSql.Err())
+}
+
+/*
+*
+GetUnmatchedColumns retorna las columnas del resultado que no se relacionaron con ningún campo del struct
+en la ultima llamada a Scan, ScanOne o ScanAll.
+*/
+func (q *Querys) GetUnmatchedColumns() []string {
+	return q.unmatched
+}
+
+/** lee la fila actual en los campos del struct, las columnas sin campo se descartan */
+func scanStruct(rows *sql.Rows, cols []string, fields map[string][]int, v reflect.Value) error {
+	pointers := make([]interface{}, len(cols))
+	for i, col := range cols {
+		index, ok := fields[strings.ToLower(col)]
+		if !ok {
+			pointers[i] = new(interface{})
+			continue
+		}
+		pointers[i] = v.FieldByIndex(index).Addr().Interface()
+	}
+	return rows.Scan(pointers...)
+}
+
+func unmatchedColumns(cols []string, fields map[string][]int) []string {
+	var unmatched []string
+	for _, col := range cols {
+		if _, ok := fields[strings.ToLower(col)]; !ok {
+			unmatched = append(unmatched, col)
+		}
+	}
+	return unmatched
+}
+
+/*
+getStructFields retorna la relación columna => índice del campo de un tipo struct,
+incluye los campos de los structs embebidos (no punteros).
+*/
+func getStructFields(t reflect.Type) map[string][]int {
+	if cached, ok := structFieldsCache.Load(t); ok {
+		return cached.(map[string][]int)
+	}
+	fields := make(map[string][]int)
+	collectStructFields(t, nil, fields)
+	structFieldsCache.Store(t, fields)
+	return fields
+}
+
+func collectStructFields(t reflect.Type, parent []int, fields map[string][]int) {
+	var embedded [][]int
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		index := append(append([]int{}, parent...), i)
+		tag := f.Tag.Get("db")
+		if tag == "-" {
+			continue
+		}
+		if f.Anonymous && tag == "" && f.Type.Kind() == reflect.Struct {
+			embedded = append(embedded, index)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if name == "" {
+			name = f.Name
+		}
+		name = strings.ToLower(name)
+		if _, exists := fields[name]; !exists {
+			fields[name] = index
+		}
+	}
+	/** los campos de los structs embebidos no reemplazan a los del struct contenedor */
+	for _, index := range embedded {
+		collectStructFields(t.FieldByIndex(index[len(parent):]).Type, index, fields)
+	}
+}
+
+/** indica si el tipo se lee como un solo valor (tipos simples, time.Time o sql.Scanner) y no campo por campo */
+func isScalarType(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() != reflect.Struct || t == timeType || reflect.PointerTo(t).Implements(scannerType)
+}
@@ -0,0 +1,238 @@
+package basicgorm
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"sync"
+)
+
+/*
+StmtCacheStats contiene las métricas de la cache de sentencias preparadas de un pool de conexiones.
+*/
+type StmtCacheStats struct {
+	Hits      uint64 //Cantidad de sentencias reutilizadas desde la cache
+	Misses    uint64 //Cantidad de sentencias que se tuvieron que preparar
+	Evictions uint64 //Cantidad de sentencias cerradas por superar el tamaño de la cache
+	Size      int    //Cantidad de sentencias que se encuentran en la cache
+}
+
+/** HitRate retorna la proporción (0 a 1) de sentencias reutilizadas desde la cache */
+func (s StmtCacheStats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+/*
+GetStmtCacheStats retorna las métricas de la cache de sentencias preparadas del pool de la configuración recibida.
+
+Parámetros:
+  - config: configuración de la conexión (Cloud, Database o Config).
+
+Devuelve:
+  - Las métricas de la cache, en cero si el pool aun no fue creado.
+  - Un error, si la configuración no es valida.
+*/
+func GetStmtCacheStats(config QConfig) (StmtCacheStats, error) {
+	key, _, err := poolKey(config)
+	if err != nil {
+		return StmtCacheStats{}, err
+	}
+	poolMutex.Lock()
+	db, ok := pools[key]
+	cache := stmtCaches[db]
+	poolMutex.Unlock()
+	if !ok || cache == nil {
+		return StmtCacheStats{}, nil
+	}
+	return cache.getStats(), nil
+}
+
+/*
+stmtCache guarda las sentencias preparadas de un pool por el texto de la consulta, al superar el tamaño
+se cierra la sentencia utilizada hace mas tiempo (LRU). Una sentencia que se esta utilizando se cierra
+recién cuando se libera.
+*/
+type stmtCache struct {
+	mu    sync.Mutex
+	db    *sql.DB
+	size  int
+	ll    *list.List
+	items map[string]*list.Element
+	stats StmtCacheStats
+}
+
+type stmtEntry struct {
+	query   string
+	stmt    *sql.Stmt
+	refs    int
+	evicted bool
+}
+
+func newStmtCache(db *sql.DB, size int) *stmtCache {
+	return &stmtCache{db: db, size: size, ll: list.New(), items: make(map[string]*list.Element)}
+}
+
+/*
+prepare retorna la sentencia preparada para la consulta y la función que la libera,
+la cual se debe de llamar al terminar de utilizar la sentencia (y sus filas).
+Si la cache esta desactivada la sentencia se cierra al liberarla.
+*/
+func (c *stmtCache) prepare(ctx context.Context, db *sql.DB, query string) (*sql.Stmt, func(), error) {
+	if c == nil || c.getSize() <= 0 {
+		stmt, err := db.PrepareContext(ctx, query)
+		if err != nil {
+			return nil, nil, err
+		}
+		return stmt, func() { stmt.Close() }, nil
+	}
+
+	c.mu.Lock()
+	if el, ok := c.items[query]; ok {
+		c.stats.Hits++
+		c.ll.MoveToFront(el)
+		e := el.Value.(*stmtEntry)
+		e.refs++
+		c.mu.Unlock()
+		return e.stmt, c.releaseFunc(e), nil
+	}
+	c.stats.Misses++
+	c.mu.Unlock()
+
+	stmt, err := c.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[query]; ok {
+		/** otra consulta preparo la misma sentencia mientras tanto */
+		stmt.Close()
+		c.ll.MoveToFront(el)
+		e := el.Value.(*stmtEntry)
+		e.refs++
+		return e.stmt, c.releaseFunc(e), nil
+	}
+	e := &stmtEntry{query: query, stmt: stmt, refs: 1}
+	c.items[query] = c.ll.PushFront(e)
+	c.evictOver(c.size)
+	return stmt, c.releaseFunc(e), nil
+}
+
+func (c *stmtCache) releaseFunc(e *stmtEntry) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			c.mu.Lock()
+			e.refs--
+			closeNow := e.evicted && e.refs == 0
+			c.mu.Unlock()
+			if closeNow {
+				e.stmt.Close()
+			}
+		})
+	}
+}
+
+/** cierra las sentencias menos utilizadas hasta que la cache tenga como máximo size sentencias, requiere c.mu */
+func (c *stmtCache) evictOver(size int) {
+	for c.ll.Len() > size {
+		el := c.ll.Back()
+		e := el.Value.(*stmtEntry)
+		c.ll.Remove(el)
+		delete(c.items, e.query)
+		e.evicted = true
+		c.stats.Evictions++
+		if e.refs == 0 {
+			e.stmt.Close()
+		}
+	}
+}
+
+func (c *stmtCache) setSize(size int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.size = size
+	if size < 0 {
+		size = 0
+	}
+	c.evictOver(size)
+}
+
+func (c *stmtCache) getSize() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.size
+}
+
+func (c *stmtCache) getStats() StmtCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats
+	stats.Size = c.ll.Len()
+	return stats
+}
+
+/** cierra todas las sentencias de la cache */
+func (c *stmtCache) close() {
+	c.setSize(0)
+}
+
+/** prepara la sentencia dentro de la transacción reutilizando la sentencia de la cache del pool */
+func (c *stmtCache) prepareTx(ctx context.Context, db *sql.DB, tx *sql.Tx, query string) (*sql.Stmt, func(), error) {
+	if c == nil || c.getSize() <= 0 {
+		stmt, err := tx.PrepareContext(ctx, query)
+		if err != nil {
+			return nil, nil, err
+		}
+		return stmt, func() { stmt.Close() }, nil
+	}
+	stmt, release, err := c.prepare(ctx, db, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	txStmt := tx.StmtContext(ctx, stmt)
+	return txStmt, func() {
+		txStmt.Close()
+		release()
+	}, nil
+}
+
+/*
+queryCached ejecuta la consulta reutilizando la sentencia preparada de la cache, si tx no es nil se ejecuta dentro de la transacción.
+Las consultas sin argumentos se envían directamente (igual que lib/pq), permitiendo varias sentencias en una sola consulta.
+La función retornada libera la sentencia y se debe de llamar luego de cerrar las filas.
+*/
+func queryCached(ctx context.Context, cache *stmtCache, db *sql.DB, tx *sql.Tx, query string, args []interface{}) (*sql.Rows, func(), error) {
+	if len(args) == 0 {
+		var rows *sql.Rows
+		var err error
+		if tx != nil {
+			rows, err = tx.QueryContext(ctx, query)
+		} else {
+			rows, err = db.QueryContext(ctx, query)
+		}
+		return rows, func() {}, err
+	}
+	var stmt *sql.Stmt
+	var release func()
+	var err error
+	if tx != nil {
+		stmt, release, err = cache.prepareTx(ctx, db, tx, query)
+	} else {
+		stmt, release, err = cache.prepare(ctx, db, query)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		release()
+		return nil, nil, err
+	}
+	return rows, release, nil
+}
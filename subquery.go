@@ -0,0 +1,235 @@
+package basicgorm
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/*
+*
+JoinQuery añade una cláusula JOIN utilizando una subconsulta como tabla derivada,
+los argumentos de la subconsulta se agregan a la consulta renumerando sus placeholders ($n).
+
+Debe de llamarse antes de Where, ya que los argumentos del JOIN preceden a los de la cláusula WHERE.
+
+Ejemplo de uso:
+
+	ventas := &Querys{Table: "stock_ventas"}
+	ventas.Select("c_sucu", "sum(s_tota) as s_tota").Where("n_year", I, 2024).GroupBy("c_sucu")
+
+	queryBuilder := &Querys{Table: "conf_sucursales as a"}
+	queryBuilder.Select("a.c_sucu", "b.s_tota").JoinQuery(LEFT, ventas, "b", "a.c_sucu = b.c_sucu").Where("a.k_stad", I, 0)
+	// SELECT a.c_sucu,b.s_tota FROM conf_sucursales as a LEFT JOIN  (SELECT c_sucu,sum(s_tota) as s_tota FROM stock_ventas WHERE n_year = $1 GROUP BY c_sucu) AS b ON a.c_sucu = b.c_sucu WHERE a.k_stad = $2
+
+Parámetros:
+  - tp: Tipo de unión (INNER, LEFT, RIGHT, etc.).
+  - sub: subconsulta a unir.
+  - alias: alias de la tabla derivada.
+  - on: Condición ON para la unión.
+
+Devuelve:
+  - Un puntero al struct Querys actualizado para permitir el encadenamiento de métodos.
+*/
+func (q *Querys) JoinQuery(tp TypeJoin, sub *Querys, alias string, on string) *Querys {
+	if q.query.Where != "" {
+		return q.setError(errors.New("JoinQuery debe de llamarse antes de Where"))
+	}
+	if !identRegexp.MatchString(alias) {
+		return q.setError(fmt.Errorf("alias invalido: %q", alias))
+	}
+	if q.safe {
+		var err error
+		if on, err = quoteOn(on); err != nil {
+			return q.setError(err)
+		}
+		alias = `"` + alias + `"`
+	}
+	subQuery, err := q.bindQuery(sub)
+	if err != nil {
+		return q.setError(err)
+	}
+	q.fromArgs = len(q.args)
+	q.query.Join = append(q.query.Join, fmt.Sprintf(" %s  %s AS %s ON %s", tp, subQuery, alias, on))
+	return q
+}
+
+/*
+bindQuery agrega los argumentos de la subconsulta a la consulta y retorna la subconsulta entre paréntesis
+con sus placeholders renumerados a continuación de los argumentos existentes.
+*/
+func (q *Querys) bindQuery(sub *Querys) (string, error) {
+	if sub == nil {
+		return "", errors.New("subconsulta vacía")
+	}
+	if sub == q {
+		return "", errors.New("una consulta no puede utilizarse como su propia subconsulta")
+	}
+	if sub.err != nil {
+		return "", sub.err
+	}
+	if q.argsLen == 0 {
+		q.argsLen = len(q.args) + 1
+	}
+	subQuery := shiftPlaceholders(sub.GetQuery(), q.argsLen-1)
+	subArgs := sub.getArgs()
+	q.args = append(q.args, subArgs...)
+	q.argsLen += len(subArgs)
+	return fmt.Sprintf("(%s)", subQuery), nil
+}
+
+/*
+shiftPlaceholders incrementa en offset el número de cada placeholder ($n) de la consulta,
+se omiten los textos entre comillas simples y dobles.
+*/
+func shiftPlaceholders(query string, offset int) string {
+	if offset == 0 {
+		return query
+	}
+	var b strings.Builder
+	var quote byte
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		if quote != 0 {
+			if c == quote {
+				quote = 0
+			}
+			b.WriteByte(c)
+			continue
+		}
+		if c == '\'' || c == '"' {
+			quote = c
+			b.WriteByte(c)
+			continue
+		}
+		if c != '$' || (i > 0 && isIdentByte(query[i-1])) {
+			b.WriteByte(c)
+			continue
+		}
+		j := i + 1
+		for j < len(query) && query[j] >= '0' && query[j] <= '9' {
+			j++
+		}
+		if j == i+1 {
+			b.WriteByte(c)
+			continue
+		}
+		n, _ := strconv.Atoi(query[i+1 : j])
+		b.WriteString("$" + strconv.Itoa(n+offset))
+		i = j - 1
+	}
+	return b.String()
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+/*
+*
+With agrega una expresión de tabla común (WITH nombre AS (...)) a la consulta, el nombre se puede utilizar
+como tabla en Table, Join o en otras subconsultas. Los argumentos de la subconsulta se agregan a la consulta renumerando sus placeholders ($n).
+
+Debe de llamarse antes de Where, ya que los argumentos del WITH preceden a los de la cláusula WHERE.
+
+Ejemplo de uso:
+
+	compras := &Querys{Table: "stock_compras"}
+	compras.Select("c_prod", "sum(s_cant) as s_cant").Where("n_year", I, 2024).GroupBy("c_prod")
+
+	queryBuilder := &Querys{Table: "compras as a"}
+	queryBuilder.With("compras", compras).Select("a.c_prod", "a.s_cant").Where("a.s_cant", MY, 0)
+	// WITH compras AS (SELECT c_prod,sum(s_cant) as s_cant FROM stock_compras WHERE n_year = $1 GROUP BY c_prod) SELECT a.c_prod,a.s_cant FROM compras as a WHERE a.s_cant > $2
+
+Parámetros:
+  - name: nombre de la expresión, puede incluir la lista de columnas (por ejemplo "arbol(id, padre)").
+  - sub: subconsulta de la expresión.
+
+Devuelve:
+  - Un puntero al struct Querys actualizado para permitir el encadenamiento de métodos.
+*/
+func (q *Querys) With(name string, sub *Querys) *Querys {
+	if q.query.Where != "" {
+		return q.setError(errors.New("With debe de llamarse antes de Where"))
+	}
+	name, err := q.cteName(name)
+	if err != nil {
+		return q.setError(err)
+	}
+	subQuery, err := q.bindQuery(sub)
+	if err != nil {
+		return q.setError(err)
+	}
+	q.fromArgs = len(q.args)
+	q.query.With = append(q.query.With, fmt.Sprintf("%s AS %s", name, subQuery))
+	return q
+}
+
+/*
+*
+WithRecursive agrega una expresión de tabla común recursiva, la consulta se genera con WITH RECURSIVE.
+La subconsulta debe de unir el caso base y el caso recursivo con UNION o UNION ALL.
+
+Ejemplo de uso:
+
+	arbol := &Querys{}
+	arbol.SetQueryString("SELECT id, padre FROM conf_menu WHERE id = $1 UNION ALL SELECT m.id, m.padre FROM conf_menu m INNER JOIN arbol a ON m.padre = a.id", 1)
+
+	queryBuilder := &Querys{Table: "arbol"}
+	queryBuilder.WithRecursive("arbol(id, padre)", arbol).Select()
+	// WITH RECURSIVE arbol(id, padre) AS (SELECT id, padre FROM conf_menu WHERE id = $1 UNION ALL ...) SELECT * FROM arbol
+
+Parámetros:
+  - name: nombre de la expresión, puede incluir la lista de columnas.
+  - sub: subconsulta de la expresión.
+
+Devuelve:
+  - Un puntero al struct Querys actualizado para permitir el encadenamiento de métodos.
+*/
+func (q *Querys) WithRecursive(name string, sub *Querys) *Querys {
+	q.query.recursive = true
+	return q.With(name, sub)
+}
+
+/** valida el nombre de la expresión de tabla común y su lista de columnas opcional */
+func (q *Querys) cteName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	var columns []string
+	if i := strings.Index(name, "("); i >= 0 {
+		if !strings.HasSuffix(name, ")") {
+			return "", fmt.Errorf("nombre invalido para WITH: %q", name)
+		}
+		for _, column := range strings.Split(name[i+1:len(name)-1], ",") {
+			columns = append(columns, strings.TrimSpace(column))
+		}
+		name = strings.TrimSpace(name[:i])
+	}
+	for _, v := range append([]string{name}, columns...) {
+		if !identRegexp.MatchString(v) {
+			return "", fmt.Errorf("nombre invalido para WITH: %q", v)
+		}
+	}
+	if q.safe {
+		name = `"` + name + `"`
+		for i, column := range columns {
+			columns[i] = `"` + column + `"`
+		}
+	}
+	if len(columns) > 0 {
+		name += "(" + strings.Join(columns, ", ") + ")"
+	}
+	return name, nil
+}
+
+/** retorna la cláusula WITH que antecede a la consulta, vació si no existen expresiones */
+func (q *Querys) getWith() string {
+	if len(q.query.With) == 0 {
+		return ""
+	}
+	with := "WITH "
+	if q.query.recursive {
+		with = "WITH RECURSIVE "
+	}
+	return with + strings.Join(q.query.With, ", ") + " "
+}
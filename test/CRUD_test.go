@@ -1,7 +1,12 @@
 package test
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/deybin/basicgorm"
 	"github.com/deybin/basicgorm/test/table"
@@ -292,3 +297,450 @@ func TestCRUD_Multiple_Set_Single(t *testing.T) {
 	}
 
 }
+
+func TestCRUD_Single_Returning(t *testing.T) {
+	dataInsert := map[string]interface{}{
+		"c_sucu": "005",
+		"l_sucu": "sucursal de prueba",
+		"l_dire": "sin información",
+	}
+
+	crud := basicgorm.SqlExecSingle{}
+	err := crud.New(new(table.Sucursal).New(), dataInsert).Insert("c_sucu", "l_sucu")
+	if err != nil {
+		t.Errorf("se esperaba este error: %s", err.Error())
+		return
+	}
+
+	err = crud.Exec("new_capital")
+	if err != nil {
+		t.Errorf("se esperaba este error: %s", err.Error())
+		return
+	}
+
+	var sucursal struct {
+		Codigo string `db:"c_sucu"`
+		Nombre string `db:"l_sucu"`
+	}
+	if err := crud.ScanReturning(&sucursal); err != nil {
+		t.Errorf("se esperaba este error: %s", err.Error())
+		return
+	}
+	if sucursal.Codigo != "005" {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", "005", sucursal.Codigo)
+	}
+}
+
+func TestCRUD_Single_Returning_Multiple(t *testing.T) {
+	dataInsert := []map[string]interface{}{
+		{"c_sucu": "007", "l_sucu": "sucursal de prueba", "l_dire": "sin información"},
+		{"c_sucu": "008", "l_sucu": "sucursal de prueba", "l_dire": "sin información"},
+	}
+
+	crud := basicgorm.SqlExecSingle{}
+	err := crud.New(new(table.Sucursal).New(), dataInsert...).Insert("c_sucu")
+	if err != nil {
+		t.Errorf("se esperaba este error: %s", err.Error())
+		return
+	}
+
+	/** con RETURNING se genera una sentencia por registro */
+	if len(crud.GetQuery()) != len(dataInsert) {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", len(dataInsert), len(crud.GetQuery()))
+		return
+	}
+
+	err = crud.Exec("new_capital")
+	if err != nil {
+		t.Errorf("se esperaba este error: %s", err.Error())
+		return
+	}
+
+	/** un resultado por cada registro enviado */
+	returning := crud.GetReturning()
+	if len(returning) != 2 {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", 2, len(returning))
+		return
+	}
+	for i, v := range dataInsert {
+		if len(returning[i]) != 1 || fmt.Sprint(returning[i][0]["c_sucu"]) != v["c_sucu"] {
+			t.Errorf("Se esperaba: %v, pero se obtuvo %v", v["c_sucu"], returning[i])
+		}
+	}
+}
+
+func TestCRUD_Single_Returning_UpdateDelete(t *testing.T) {
+	dataUpdate := map[string]interface{}{
+		"l_alma": "principal",
+		"where":  map[string]interface{}{"c_sucu": "001", "c_alma": "002"},
+	}
+
+	crud := basicgorm.SqlExecSingle{}
+	err := crud.New(new(table.Store).New(), dataUpdate).Update("c_alma", "l_alma")
+	if err != nil {
+		t.Errorf("se esperaba este error: %s", err.Error())
+		return
+	}
+	r := crud.GetQuery()[0]
+	result := "UPDATE requ_almacen SET l_alma= $1 WHERE c_sucu = $2 AND c_alma = $3 RETURNING c_alma, l_alma"
+	if r != result {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", result, r)
+	}
+
+	err = crud.Exec("new_capital")
+	if err != nil {
+		t.Errorf("se esperaba este error: %s", err.Error())
+		return
+	}
+	var almacen struct {
+		Codigo string `db:"c_alma"`
+		Nombre string `db:"l_alma"`
+	}
+	if err := crud.ScanReturning(&almacen); err != nil {
+		t.Errorf("se esperaba este error: %s", err.Error())
+		return
+	}
+	if almacen.Codigo != "002" || almacen.Nombre != "principal" {
+		t.Errorf("valores incorrectos: %+v", almacen)
+	}
+
+	err = crud.New(new(table.Sucursal).New(), map[string]interface{}{"c_sucu": "007"}).Delete("c_sucu")
+	if err != nil {
+		t.Errorf("se esperaba este error: %s", err.Error())
+		return
+	}
+	r = crud.GetQuery()[0]
+	result = "DELETE FROM requ_sucursal  WHERE c_sucu = $1 RETURNING c_sucu"
+	if r != result {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", result, r)
+	}
+
+	err = crud.Exec("new_capital")
+	if err != nil {
+		t.Errorf("se esperaba este error: %s", err.Error())
+		return
+	}
+	returning := crud.GetReturning()
+	if len(returning) != 1 || len(returning[0]) != 1 || fmt.Sprint(returning[0][0]["c_sucu"]) != "007" {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", "007", returning)
+	}
+}
+
+func TestCRUD_Single_Upsert(t *testing.T) {
+	dataUpsert := map[string]interface{}{
+		"c_sucu": "001",
+		"c_alma": "002",
+		"l_alma": "almacen principal",
+	}
+
+	crud := basicgorm.SqlExecSingle{}
+	err := crud.New(new(table.Store).New(), dataUpsert).Upsert()
+	if err != nil {
+		t.Errorf("se esperaba este error: %s", err.Error())
+		return
+	}
+
+	err = crud.Exec("new_capital")
+	if err != nil {
+		t.Errorf("se esperaba este error: %s", err.Error())
+		return
+	}
+}
+
+type producto struct{}
+
+func (p producto) GetTableName() string { return "requ_producto" }
+func (p producto) GetSchemaInsert() []basicgorm.Fields {
+	return []basicgorm.Fields{
+		{Name: "c_prod", Description: "producto", Type: basicgorm.String, PrimaryKey: true, Required: true, ValidateType: basicgorm.TypeStrings{}},
+		{Name: "l_prod", Description: "descripción", Type: basicgorm.String, Update: true, ValidateType: basicgorm.TypeStrings{}},
+		{Name: "l_esta", Description: "estado", Type: basicgorm.String, Update: true, Default: "activo", ValidateType: basicgorm.TypeStrings{}},
+	}
+}
+func (p producto) GetSchemaUpdate() []basicgorm.Fields { return p.GetSchemaInsert() }
+func (p producto) GetSchemaDelete() []basicgorm.Fields { return p.GetSchemaInsert() }
+
+func TestCRUD_UpsertDefault(t *testing.T) {
+	crud := basicgorm.SqlExecSingle{}
+	if err := crud.New(producto{}, map[string]interface{}{"c_prod": "001", "l_prod": "arroz"}).Upsert(); err != nil {
+		t.Errorf("no se esperaba error: %s", err.Error())
+		return
+	}
+
+	/** l_esta se inserta con su valor Default pero no se actualiza */
+	result := "INSERT INTO requ_producto (c_prod, l_prod, l_esta) VALUES($1, $2, $3) ON CONFLICT (c_prod) DO UPDATE SET l_prod = EXCLUDED.l_prod"
+	if r := crud.GetQuery()[0]; r != result {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", result, r)
+	}
+}
+
+func TestCRUD_BulkCopy(t *testing.T) {
+	var rows []map[string]interface{}
+	for i := 0; i < 1000; i++ {
+		rows = append(rows, map[string]interface{}{
+			"c_sucu": "001",
+			"c_alma": fmt.Sprintf("%03d", i),
+			"l_alma": "almacen de prueba",
+		})
+	}
+
+	crud := basicgorm.SqlExecMultiple{}
+	err := crud.New("new_capital").BulkCopy(new(table.Store).New(), rows...)
+	if err != nil {
+		t.Errorf("se esperaba este error: %s", err.Error())
+		return
+	}
+}
+
+func TestCRUD_BulkCopy_Transaction(t *testing.T) {
+	/** registros duplicados: el COPY falla dentro de la transacción abierta por ExecTransaction */
+	rows := []map[string]interface{}{
+		{"c_sucu": "001", "c_alma": "901", "l_alma": "almacen de prueba"},
+		{"c_sucu": "001", "c_alma": "901", "l_alma": "almacen de prueba"},
+	}
+
+	crud := basicgorm.SqlExecMultiple{}
+	crud.New("new_capital")
+	tx := crud.SetInfo(new(table.Store).New(), map[string]interface{}{"c_sucu": "001", "c_alma": "900", "l_alma": "almacen de prueba"})
+	if err := tx.Insert(); err != nil {
+		t.Errorf("no se esperaba error: %s", err.Error())
+		return
+	}
+	if err := crud.ExecTransaction(tx); err != nil {
+		t.Errorf("no se esperaba error: %s", err.Error())
+		return
+	}
+	if err := crud.BulkCopy(new(table.Store).New(), rows...); err == nil {
+		t.Errorf("se esperaba error por registros duplicados")
+	}
+
+	/** la transacción sigue abierta para que quien la abrió la revierta */
+	if err := crud.Rollback(); err != nil {
+		t.Errorf("no se esperaba error: %s", err.Error())
+	}
+}
+
+func TestCRUD_BulkCopy_Validation(t *testing.T) {
+	rows := []map[string]interface{}{
+		{"c_sucu": "001", "c_alma": "001", "l_alma": "almacen de prueba"},
+		{"c_sucu": "1", "c_alma": "002", "l_alma": "almacen de prueba"},
+		{"c_sucu": "001", "c_alma": "003"},
+	}
+
+	crud := basicgorm.SqlExecMultiple{}
+	err := crud.New("new_capital").BulkCopy(new(table.Store).New(), rows...)
+	var errs basicgorm.BulkErrors
+	if !errors.As(err, &errs) {
+		t.Errorf("se esperaba errores de validación: %v", err)
+		return
+	}
+	if len(errs) != 2 || errs[0].Index != 1 || errs[1].Index != 2 {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", "registros 1 y 2", errs)
+	}
+}
+
+func TestCRUD_ValidationErrors(t *testing.T) {
+	dataInsert := map[string]interface{}{
+		"c_sucu": "1",
+		"c_alma": 2,
+	}
+
+	crud := basicgorm.SqlExecSingle{}
+	err := crud.New(new(table.Store).New(), dataInsert).Insert()
+	var errs basicgorm.ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Errorf("se esperaba errores de validación: %v", err)
+		return
+	}
+
+	codes := map[string]string{}
+	for _, v := range errs {
+		codes[v.Field] = v.Code
+	}
+	result := map[string]string{"c_sucu": basicgorm.CodeMinLength, "c_alma": basicgorm.CodeInvalidType, "l_alma": basicgorm.CodeRequired}
+	if fmt.Sprint(codes) != fmt.Sprint(result) {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", result, codes)
+	}
+	if errs.Field("c_sucu")[0].Params["min"] != 3 {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", 3, errs.Field("c_sucu")[0].Params)
+	}
+	if !strings.HasPrefix(err.Error(), "1.- El campo c_sucu") {
+		t.Errorf("Se esperaba el mensaje numerado, pero se obtuvo %v", err.Error())
+	}
+
+	dataUpdate := map[string]interface{}{
+		"c_sucu": "001",
+		"where":  map[string]interface{}{"c_alma": "002"},
+	}
+	err = crud.New(new(table.Store).New(), dataUpdate).Update()
+	if !errors.As(err, &errs) || errs[0].Code != basicgorm.CodeNotUpdatable {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", basicgorm.CodeNotUpdatable, err)
+	}
+}
+
+func TestCRUD_ValidationCatalog(t *testing.T) {
+	dataInsert := map[string]interface{}{
+		"c_sucu": "001",
+		"c_alma": "002",
+	}
+
+	crud := basicgorm.SqlExecSingle{}
+	err := crud.New(new(table.Store).New(), dataInsert).Insert()
+	result := "1.- El campo l_alma es Requerido\n"
+	if err == nil || err.Error() != result {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", result, err)
+		return
+	}
+
+	r := basicgorm.Translate(err, basicgorm.GetCatalog("en")).Error()
+	result = "1.- The field l_alma is required\n"
+	if r != result {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", result, r)
+	}
+
+	basicgorm.RegisterCatalog("pt", basicgorm.MessageBundle{basicgorm.CodeRequired: "O campo {field} é obrigatório"})
+	r = basicgorm.Translate(err, basicgorm.CatalogFromContext(basicgorm.WithCatalog(context.Background(), "pt"))).Error()
+	result = "1.- O campo l_alma é obrigatório\n"
+	if r != result {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", result, r)
+	}
+
+	r = basicgorm.TranslateContext(basicgorm.WithCatalog(context.Background(), "en"), err).Error()
+	result = "1.- The field l_alma is required\n"
+	if r != result {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", result, r)
+	}
+
+	if err := basicgorm.SetCatalog("fr"); err == nil {
+		t.Errorf("se esperaba un error con un idioma no registrado")
+	}
+}
+
+func TestCRUD_ExecErrorCatalog(t *testing.T) {
+	/** los errores de ejecución conservan su código para traducirlos */
+	crud := basicgorm.SqlExecSingle{}
+	err := crud.New(new(table.Store).New()).Insert()
+	var execErr basicgorm.ExecError
+	if !errors.As(err, &execErr) || execErr.Code != basicgorm.CodeNoInsertData {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", basicgorm.CodeNoInsertData, err)
+		return
+	}
+	r := basicgorm.Translate(err, basicgorm.GetCatalog("en")).Error()
+	result := "there is no data to insert"
+	if r != result {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", result, r)
+	}
+
+	/** BulkCopyContext genera sus errores con el idioma del contexto */
+	ctx := basicgorm.WithCatalog(context.Background(), "en")
+	multiple := basicgorm.SqlExecMultiple{}
+	err = multiple.New("new_capital").BulkCopyContext(ctx, new(table.Store).New())
+	if err == nil || err.Error() != result {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", result, err)
+	}
+	err = multiple.BulkCopyContext(ctx, new(table.Store).New(), map[string]interface{}{"c_sucu": "001", "c_alma": "002"})
+	result = "registro 0: 1.- The field l_alma is required\n"
+	if err == nil || err.Error() != result {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", result, err)
+	}
+}
+
+type documento struct{}
+
+func (d documento) GetTableName() string { return "requ_documentos" }
+func (d documento) GetSchemaInsert() []basicgorm.Fields {
+	return []basicgorm.Fields{
+		{Name: "b_acti", Description: "activo", Type: basicgorm.Bool, Required: true, ValidateType: basicgorm.TypeBool{}},
+		{Name: "f_emis", Description: "emisión", Type: basicgorm.Time, Required: true, ValidateType: basicgorm.TypeTime{
+			Min: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		}},
+		{Name: "x_arch", Description: "archivo", Type: basicgorm.Bytes, ValidateType: basicgorm.TypeBytes{Max: 16, ContentTypes: []string{"text/plain"}}},
+	}
+}
+func (d documento) GetSchemaUpdate() []basicgorm.Fields { return d.GetSchemaInsert() }
+func (d documento) GetSchemaDelete() []basicgorm.Fields { return d.GetSchemaInsert() }
+
+func TestCRUD_BoolTimeBytes(t *testing.T) {
+	crud := basicgorm.SqlExecSingle{}
+	err := crud.New(documento{}, map[string]interface{}{"b_acti": "true", "f_emis": "2024-05-10", "x_arch": "hola"}).Insert()
+	if err != nil {
+		t.Errorf("no se esperaba error: %s", err.Error())
+		return
+	}
+	args := crud.GetArgs()[0]
+	if args[0] != true || args[1] != time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC) || string(args[2].([]byte)) != "hola" {
+		t.Errorf("valores incorrectos: %v", args)
+	}
+
+	err = crud.New(documento{}, map[string]interface{}{"b_acti": int64(2), "f_emis": "2023-12-31", "x_arch": []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n'}}).Insert()
+	var errs basicgorm.ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Errorf("se esperaba errores de validación: %v", err)
+		return
+	}
+	codes := []string{}
+	for _, v := range errs {
+		codes = append(codes, v.Code)
+	}
+	result := []string{basicgorm.CodeInvalidType, basicgorm.CodeMin, basicgorm.CodeContentType}
+	if fmt.Sprint(codes) != fmt.Sprint(result) {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", result, codes)
+	}
+}
+
+type lote struct{}
+
+func (l lote) GetTableName() string { return "requ_lotes" }
+func (l lote) GetSchemaInsert() []basicgorm.Fields {
+	return []basicgorm.Fields{
+		{Name: "n_lote", Description: "lote", Type: basicgorm.Int, Required: true, ValidateType: basicgorm.TypeInt64{}},
+	}
+}
+func (l lote) GetSchemaUpdate() []basicgorm.Fields { return l.GetSchemaInsert() }
+func (l lote) GetSchemaDelete() []basicgorm.Fields { return l.GetSchemaInsert() }
+
+func TestCRUD_InsertBatch(t *testing.T) {
+	var dataInsert []map[string]interface{}
+	for i := 0; i < 70000; i++ {
+		dataInsert = append(dataInsert, map[string]interface{}{"n_lote": int64(i)})
+	}
+	crud := basicgorm.SqlExecSingle{}
+	if err := crud.New(lote{}, dataInsert...).Insert(); err != nil {
+		t.Errorf("no se esperaba error: %s", err.Error())
+		return
+	}
+
+	/** 65535 parámetros por sentencia con una sola columna */
+	query := crud.GetQuery()
+	args := crud.GetArgs()
+	if len(query) != 2 || len(args[0]) != 65535 || len(args[1]) != 70000-65535 {
+		t.Errorf("Se esperaba: %v sentencias, pero se obtuvo %v", 2, len(query))
+		return
+	}
+	if !strings.HasSuffix(query[1], "($4464), ($4465)") || args[1][0] != int64(65535) {
+		t.Errorf("la segunda sentencia debe de continuar con el registro 65535: %s", query[1][len(query[1])-30:])
+	}
+}
+
+func TestCRUD_InsertBatchDefault(t *testing.T) {
+	dataInsert := []map[string]interface{}{
+		{"c_sucu": "003", "l_sucu": "sucursal de prueba", "l_dire": "sin información", "n_celu": "987654321"},
+		{"c_sucu": "004", "l_sucu": "sucursal de prueba", "l_dire": "sin información", "c_ubig": "120119"},
+	}
+	crud := basicgorm.SqlExecSingle{}
+	if err := crud.New(new(table.Sucursal).New(), dataInsert...).Insert(); err != nil {
+		t.Errorf("no se esperaba error: %s", err.Error())
+		return
+	}
+
+	r := crud.GetQuery()
+	result := "[INSERT INTO requ_sucursal (c_sucu, l_sucu, l_dire, c_ubig, n_celu) VALUES($1, $2, $3, DEFAULT, $4), ($5, $6, $7, $8, DEFAULT)]"
+	if fmt.Sprint(r) != result {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", result, r)
+	}
+	args := fmt.Sprint(crud.GetArgs())
+	resultArgs := "[[003 sucursal de prueba sin información 987654321 004 sucursal de prueba sin información 120119]]"
+	if args != resultArgs {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", resultArgs, args)
+	}
+}
@@ -0,0 +1,78 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/deybin/basicgorm"
+	"github.com/deybin/basicgorm/test/table"
+)
+
+func TestQueryTimeout(t *testing.T) {
+	Query := new(basicgorm.Querys)
+	_, err := Query.SetQueryString("SELECT pg_sleep($1)", 2).Exec(basicgorm.QConfig{Database: "new_capital", Timeout: 100 * time.Millisecond}).All()
+	if !errors.Is(err, basicgorm.ErrQueryCanceled) {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", basicgorm.ErrQueryCanceled, err)
+	}
+}
+
+func TestQueryContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	Query := new(basicgorm.Querys)
+	_, err := Query.SetQueryString("SELECT pg_sleep($1)", 2).ExecContext(ctx, basicgorm.QConfig{Database: "new_capital"}).All()
+	if !errors.Is(err, basicgorm.ErrQueryCanceled) {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", basicgorm.ErrQueryCanceled, err)
+	}
+
+	/** un contexto ya cancelado no ejecuta la consulta */
+	canceled, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+	_, err = basicgorm.NewQuery[cliente]("requ_clientes").Select("n_docu", "l_clie").FindContext(canceled, basicgorm.QConfig{Database: "new_capital"})
+	if !errors.Is(err, basicgorm.ErrQueryCanceled) {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", basicgorm.ErrQueryCanceled, err)
+	}
+	_, err = new(basicgorm.Querys).SetTable("requ_clientes").CountContext(canceled, basicgorm.QConfig{Database: "new_capital"})
+	if !errors.Is(err, basicgorm.ErrQueryCanceled) {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", basicgorm.ErrQueryCanceled, err)
+	}
+}
+
+func TestCRUD_ExecContextCanceled(t *testing.T) {
+	dataInsert := map[string]interface{}{
+		"c_sucu": "006",
+		"l_sucu": "sucursal de prueba",
+		"l_dire": "sin información",
+	}
+
+	crud := basicgorm.SqlExecSingle{}
+	if err := crud.New(new(table.Sucursal).New(), dataInsert).Insert(); err != nil {
+		t.Errorf("no se esperaba error: %s", err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := crud.ExecContext(ctx, "new_capital")
+	if !errors.Is(err, basicgorm.ErrQueryCanceled) {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", basicgorm.ErrQueryCanceled, err)
+	}
+}
+
+func TestConnectContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	/** con el contexto cancelado no se intenta la conexión */
+	config := basicgorm.QConfig{Config: &basicgorm.Config{Host: "10.255.255.1", DBName: "sin_servidor", ConnectTimeout: 10 * time.Second}}
+	_, err := basicgorm.GetPoolContext(ctx, config)
+	if !errors.Is(err, basicgorm.ErrQueryCanceled) {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", basicgorm.ErrQueryCanceled, err)
+	}
+
+	err = new(basicgorm.Querys).SetTable("requ_clientes").ConnectContext(ctx, config).GetErrors()
+	if !errors.Is(err, basicgorm.ErrQueryCanceled) {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", basicgorm.ErrQueryCanceled, err)
+	}
+}
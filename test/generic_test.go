@@ -0,0 +1,105 @@
+package test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/deybin/basicgorm"
+)
+
+type cliente struct {
+	Documento string `db:"n_docu"`
+	Nombre    string `db:"l_clie"`
+	Ubigeo    *string
+}
+
+func TestQueryGenericSintaxis(t *testing.T) {
+	query := basicgorm.NewQuery[cliente]("requ_clientes").Where("c_ubig", basicgorm.I, "120119").And("n_docu", basicgorm.IN, []interface{}{"47727049", "20060977"}).OrderBy("n_docu")
+
+	r := query.GetQuery()
+	result := "SELECT * FROM requ_clientes WHERE c_ubig = $1 AND n_docu IN ($2,$3) ORDER BY n_docu"
+	if r != result {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", result, r)
+	}
+}
+
+func TestQueryGenericFind(t *testing.T) {
+	r, err := basicgorm.NewQuery[cliente]("requ_clientes").Select("n_docu", "l_clie", "c_ubig").Where("c_ubig", basicgorm.I, "120119").Find(basicgorm.QConfig{Database: "new_capital"})
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(r)
+}
+
+func TestQueryGenericEach(t *testing.T) {
+	n := 0
+	err := basicgorm.NewQuery[cliente]("requ_clientes").Select("n_docu", "l_clie").Where("c_ubig", basicgorm.I, "120119").Each(basicgorm.QConfig{Database: "new_capital"}, func(c cliente) error {
+		n++
+		return nil
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(n)
+}
+
+func TestQueryRowsNotExecuted(t *testing.T) {
+	rows := new(basicgorm.Querys).SetTable("requ_clientes").Select().Rows()
+	if rows.Next() || rows.Err() == nil {
+		t.Errorf("se esperaba un error al recorrer una consulta no ejecutada")
+	}
+
+	err := new(basicgorm.Querys).SetTable("requ_clientes").Select().EachCursor(100, func(row map[string]interface{}) error {
+		return nil
+	})
+	if err == nil {
+		t.Errorf("se esperaba un error al utilizar EachCursor sin Connect")
+	}
+}
+
+func TestQueryGenericFirstOffset(t *testing.T) {
+	config := basicgorm.QConfig{Database: "new_capital"}
+	r, err := basicgorm.NewQuery[cliente]("requ_clientes").Select("n_docu", "l_clie").OrderBy("n_docu").Limit(2).Find(config)
+	if err != nil || len(r) != 2 {
+		t.Errorf("se esperaba dos filas: %v", err)
+		return
+	}
+
+	query := basicgorm.NewQuery[cliente]("requ_clientes").Select("n_docu", "l_clie").OrderBy("n_docu").Limit(10, 1)
+	first, err := query.First(config)
+	if err != nil {
+		t.Errorf("no se esperaba error: %s", err.Error())
+		return
+	}
+	if first.Documento != r[1].Documento {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", r[1].Documento, first.Documento)
+	}
+
+	result := "SELECT n_docu,l_clie FROM requ_clientes ORDER BY n_docu LIMIT 10 OFFSET 1"
+	if q := query.GetQuery(); q != result {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", result, q)
+	}
+}
+
+func TestQueryGenericReuse(t *testing.T) {
+	config := basicgorm.QConfig{Database: "new_capital"}
+	query := basicgorm.NewQuery[cliente]("requ_clientes").Select("n_docu", "columna_inexistente").Where("c_ubig", basicgorm.I, "120119")
+	if _, err := query.Find(config); err == nil {
+		t.Errorf("se esperaba error por la columna inexistente")
+		return
+	}
+
+	/** el error de la ejecución anterior no impide volver a ejecutar la consulta corregida */
+	r, err := query.Select("n_docu", "l_clie").Find(config)
+	if err != nil {
+		t.Errorf("no se esperaba error: %s", err.Error())
+		return
+	}
+	fmt.Println(len(r))
+
+	/** los errores del constructor se conservan y la consulta no se ejecuta */
+	query.Builder().SafeIdentifiers().OrderBy("n_docu; DROP TABLE requ_clientes")
+	if _, err := query.First(config); err == nil || err != query.Builder().GetErrors() {
+		t.Errorf("se esperaba el error del constructor: %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+package test
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/deybin/basicgorm"
+	"github.com/deybin/basicgorm/test/table"
+)
+
+/** go test ./test -run TestGolden -update regenera los archivos de testdata */
+var update = flag.Bool("update", false, "actualiza los archivos golden de testdata")
+
+func checkGolden(t *testing.T, name string, crud *basicgorm.SqlExecSingle) {
+	t.Helper()
+	var b strings.Builder
+	args := crud.GetArgs()
+	for i, query := range crud.GetQuery() {
+		fmt.Fprintf(&b, "%s\n%v\n", query, args[i])
+	}
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.WriteFile(path, []byte(b.String()), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	golden, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != string(golden) {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", string(golden), b.String())
+	}
+}
+
+/** sucursalGolden esquema de requ_sucursal con columnas actualizables para el golden de UPDATE, sin modificar el fixture Sucursal */
+type sucursalGolden struct {
+	*table.Sucursal
+}
+
+func (s sucursalGolden) GetSchemaUpdate() []basicgorm.Fields {
+	var update []basicgorm.Fields
+	for _, v := range s.GetSchemaInsert() {
+		switch v.Name {
+		case "l_sucu", "l_dire", "n_celu", "n_tele":
+			v.Update = true
+		}
+		if v.Update || v.Where {
+			update = append(update, v)
+		}
+	}
+	return update
+}
+
+func TestGoldenSucursal(t *testing.T) {
+	dataInsert := []map[string]interface{}{
+		{"c_sucu": "003", "l_sucu": "Sucursal de Prueba", "l_dire": "sin información", "n_celu": "987654321"},
+		{"c_sucu": "004", "l_sucu": "sucursal de prueba", "l_dire": "sin información", "c_ubig": "120119"},
+	}
+	crud := basicgorm.SqlExecSingle{}
+	if err := crud.New(new(table.Sucursal).New(), dataInsert...).Insert(); err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "sucursal_insert", &crud)
+
+	dataUpdate := map[string]interface{}{
+		"l_dire": "Av. Lima 123",
+		"n_celu": "987654321",
+		"l_sucu": "Sucursal Principal",
+		"where":  map[string]interface{}{"c_sucu": "003"},
+	}
+	if err := crud.New(sucursalGolden{new(table.Sucursal).New()}, dataUpdate).Update(); err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "sucursal_update", &crud)
+
+	if err := crud.New(new(table.Sucursal).New(), map[string]interface{}{"c_sucu": "003"}).Delete(); err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "sucursal_delete", &crud)
+}
+
+func TestGoldenStore(t *testing.T) {
+	dataInsert := []map[string]interface{}{
+		{"l_alma": "Principal", "c_alma": "001", "c_sucu": "001"},
+		{"c_sucu": "001", "c_alma": "002", "l_alma": "secundario"},
+	}
+	crud := basicgorm.SqlExecSingle{}
+	if err := crud.New(new(table.Store).New(), dataInsert...).Insert(); err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "store_insert", &crud)
+
+	dataUpdate := map[string]interface{}{
+		"l_alma": "principal",
+		"where":  map[string]interface{}{"c_alma": "002", "c_sucu": "001"},
+	}
+	if err := crud.New(new(table.Store).New(), dataUpdate).Update(); err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "store_update", &crud)
+
+	if err := crud.New(new(table.Store).New(), map[string]interface{}{"c_alma": "002", "c_sucu": "001"}).Delete(); err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "store_delete", &crud)
+
+	if err := crud.New(new(table.Store).New(), dataInsert...).Upsert("c_alma"); err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "store_upsert", &crud)
+}
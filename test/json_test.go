@@ -0,0 +1,96 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/deybin/basicgorm"
+)
+
+type contacto struct {
+	Direccion string   `json:"direccion"`
+	Telefonos []string `json:"telefonos"`
+}
+
+type cliente_contacto struct{}
+
+func (c cliente_contacto) GetTableName() string { return "requ_clientes" }
+func (c cliente_contacto) GetSchemaInsert() []basicgorm.Fields {
+	return []basicgorm.Fields{
+		{Name: "n_docu", Description: "documento", Type: basicgorm.String, Required: true, PrimaryKey: true, ValidateType: basicgorm.TypeStrings{}},
+		{Name: "j_cont", Description: "contacto", Type: basicgorm.JSON, Required: true, Update: true, ValidateType: basicgorm.TypeJSON{
+			MaxDepth: 2,
+			Schema: &basicgorm.JSONSchema{
+				Type:     "object",
+				Required: []string{"direccion"},
+				Properties: map[string]*basicgorm.JSONSchema{
+					"direccion": {Type: "string"},
+					"telefonos": {Type: "array", Items: &basicgorm.JSONSchema{Type: "string"}},
+				},
+			},
+		}},
+	}
+}
+func (c cliente_contacto) GetSchemaUpdate() []basicgorm.Fields { return c.GetSchemaInsert() }
+func (c cliente_contacto) GetSchemaDelete() []basicgorm.Fields { return c.GetSchemaInsert() }
+
+func TestCRUD_JSON(t *testing.T) {
+	values := []interface{}{
+		contacto{Direccion: "Av. Lima 123", Telefonos: []string{"987654321"}},
+		map[string]interface{}{"direccion": "Av. Lima 123", "telefonos": []string{"987654321"}},
+		`{"direccion":"Av. Lima 123","telefonos":["987654321"]}`,
+	}
+	result := `{"direccion":"Av. Lima 123","telefonos":["987654321"]}`
+	for _, value := range values {
+		crud := basicgorm.SqlExecSingle{}
+		if err := crud.New(cliente_contacto{}, map[string]interface{}{"n_docu": "47727049", "j_cont": value}).Insert(); err != nil {
+			t.Errorf("no se esperaba error: %s", err.Error())
+			continue
+		}
+		if r := crud.GetArgs()[0][1]; r != result {
+			t.Errorf("Se esperaba: %v, pero se obtuvo %v", result, r)
+		}
+	}
+
+	crud := basicgorm.SqlExecSingle{}
+	err := crud.New(cliente_contacto{}, map[string]interface{}{"n_docu": "47727049", "j_cont": `{"telefonos":[987654321]}`}).Insert()
+	var errs basicgorm.ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Errorf("se esperaba errores de validación: %v", err)
+		return
+	}
+	codes := []string{}
+	for _, v := range errs {
+		codes = append(codes, fmt.Sprint(v.Code, " ", v.Params["path"]))
+	}
+	expected := []string{"json_required $.direccion", "json_type $.telefonos[0]"}
+	if fmt.Sprint(codes) != fmt.Sprint(expected) {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", expected, codes)
+	}
+
+	err = crud.New(cliente_contacto{}, map[string]interface{}{"n_docu": "47727049", "j_cont": `{"direccion":"x","otros":{"a":{"b":1}}}`}).Insert()
+	if !errors.As(err, &errs) || errs[0].Code != basicgorm.CodeMaxDepth {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", basicgorm.CodeMaxDepth, err)
+	}
+
+	err = crud.New(cliente_contacto{}, map[string]interface{}{"n_docu": "47727049", "j_cont": `{"direccion":`}).Insert()
+	if !errors.As(err, &errs) || errs[0].Code != basicgorm.CodeInvalidType {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", basicgorm.CodeInvalidType, err)
+	}
+}
+
+func TestJSONField(t *testing.T) {
+	var field basicgorm.JSONField[contacto]
+	if err := field.Scan([]byte(`{"direccion":"Av. Lima 123","telefonos":["987654321"]}`)); err != nil {
+		t.Fatal(err)
+	}
+	if field.Data.Direccion != "Av. Lima 123" || len(field.Data.Telefonos) != 1 {
+		t.Errorf("valores incorrectos: %+v", field.Data)
+	}
+	value, _ := field.Value()
+	result := `{"direccion":"Av. Lima 123","telefonos":["987654321"]}`
+	if value != result {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", result, value)
+	}
+}
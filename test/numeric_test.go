@@ -0,0 +1,156 @@
+package test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/deybin/basicgorm"
+)
+
+func TestNumericParse(t *testing.T) {
+	cases := map[string]string{
+		"1500.50": "1500.50",
+		"-0.05":   "-0.05",
+		"1.5e3":   "1500",
+		"2.5E-2":  "0.025",
+		"+007":    "7",
+		".5":      "0.5",
+	}
+	for value, result := range cases {
+		n, err := basicgorm.ParseNumeric(value)
+		if err != nil {
+			t.Errorf("no se esperaba error en %s: %s", value, err.Error())
+			continue
+		}
+		if n.String() != result {
+			t.Errorf("Se esperaba: %v, pero se obtuvo %v", result, n.String())
+		}
+	}
+	for _, value := range []string{"", "abc", "1.2.3", "--1", "1e"} {
+		if _, err := basicgorm.ParseNumeric(value); err == nil {
+			t.Errorf("se esperaba un error en %q", value)
+		}
+	}
+}
+
+func TestNumericParseLimits(t *testing.T) {
+	/** fuera de los limites de numeric (131072 dígitos enteros y 16383 decimales) */
+	for _, value := range []string{"1e20000000", "1e-3000000000", "1e99999999999999999999", "1e131072", "1e-16384", "0." + strings.Repeat("0", 16383) + "1"} {
+		if _, err := basicgorm.ParseNumeric(value); err == nil {
+			t.Errorf("se esperaba un error en %.30q", value)
+		}
+	}
+	for _, value := range []string{"1e131071", "1e-16383", "0.00e5"} {
+		if _, err := basicgorm.ParseNumeric(value); err != nil {
+			t.Errorf("no se esperaba error en %s: %s", value, err.Error())
+		}
+	}
+}
+
+func TestNumericArithmetic(t *testing.T) {
+	a := basicgorm.MustParseNumeric("0.1")
+	b := basicgorm.MustParseNumeric("0.2")
+	if r := a.Add(b).String(); r != "0.3" {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", "0.3", r)
+	}
+	if r := a.Sub(b).String(); r != "-0.1" {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", "-0.1", r)
+	}
+	interes := basicgorm.MustParseNumeric("1500.50").Mul(basicgorm.MustParseNumeric("0.025"))
+	if r := interes.String(); r != "37.51250" {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", "37.51250", r)
+	}
+
+	rounding := []struct {
+		value  string
+		mode   basicgorm.RoundingMode
+		result string
+	}{
+		{"2.345", basicgorm.RoundHalfUp, "2.35"},
+		{"2.345", basicgorm.RoundHalfEven, "2.34"},
+		{"2.355", basicgorm.RoundHalfEven, "2.36"},
+		{"-2.345", basicgorm.RoundHalfUp, "-2.35"},
+		{"2.349", basicgorm.RoundDown, "2.34"},
+		{"2.341", basicgorm.RoundUp, "2.35"},
+		{"2.3", basicgorm.RoundHalfUp, "2.30"},
+	}
+	for _, c := range rounding {
+		if r := basicgorm.MustParseNumeric(c.value).Round(2, c.mode).String(); r != c.result {
+			t.Errorf("Se esperaba: %v, pero se obtuvo %v", c.result, r)
+		}
+	}
+	/** escala negativa: redondea a decenas */
+	negative := map[string]string{"5": "10", "15": "20", "-25": "-30", "4.9": "0"}
+	for value, result := range negative {
+		if r := basicgorm.MustParseNumeric(value).Round(-1, basicgorm.RoundHalfUp).String(); r != result {
+			t.Errorf("Se esperaba: %v, pero se obtuvo %v", result, r)
+		}
+	}
+	if r := basicgorm.MustParseNumeric("-149.99").Round(-2, basicgorm.RoundHalfUp).String(); r != "-100" {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", "-100", r)
+	}
+}
+
+func TestNumericJSONAndScan(t *testing.T) {
+	var data struct {
+		Monto basicgorm.Numeric `json:"monto"`
+	}
+	if err := json.Unmarshal([]byte(`{"monto": 12345678901234567890.12}`), &data); err != nil {
+		t.Fatal(err)
+	}
+	b, _ := json.Marshal(data)
+	if string(b) != `{"monto":12345678901234567890.12}` {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", `{"monto":12345678901234567890.12}`, string(b))
+	}
+
+	var n basicgorm.Numeric
+	if err := n.Scan([]byte("99999999999999999.99")); err != nil || n.String() != "99999999999999999.99" {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v (%v)", "99999999999999999.99", n.String(), err)
+	}
+}
+
+type credito struct{}
+
+func (c credito) GetTableName() string { return "fina_credits" }
+func (c credito) GetSchemaInsert() []basicgorm.Fields {
+	max := basicgorm.MustParseNumeric("100000")
+	return []basicgorm.Fields{
+		{Name: "s_capi", Description: "capital", Type: basicgorm.Decimal, Required: true, ValidateType: basicgorm.TypeDecimal{Precision: 12, Scale: 2, Max: &max}},
+		{Name: "s_inte", Description: "interés", Type: basicgorm.Decimal, Required: true, ValidateType: basicgorm.TypeDecimal{Precision: 5, Scale: 4, Porcentaje: true}},
+		{Name: "s_mora", Description: "mora", Type: basicgorm.Decimal, ValidateType: basicgorm.TypeDecimal{Precision: 4, Scale: 2, Rounding: basicgorm.RoundHalfEven}},
+	}
+}
+func (c credito) GetSchemaUpdate() []basicgorm.Fields { return c.GetSchemaInsert() }
+func (c credito) GetSchemaDelete() []basicgorm.Fields { return c.GetSchemaInsert() }
+
+func TestCRUD_Decimal(t *testing.T) {
+	crud := basicgorm.SqlExecSingle{}
+	err := crud.New(credito{}, map[string]interface{}{"s_capi": "1500.505", "s_inte": 2.5, "s_mora": "10.125"}).Insert()
+	if err != nil {
+		t.Errorf("no se esperaba error: %s", err.Error())
+		return
+	}
+	args := crud.GetArgs()[0]
+	result := []string{"1500.51", "0.0250", "10.12"}
+	for i, v := range args {
+		if v.(basicgorm.Numeric).String() != result[i] {
+			t.Errorf("Se esperaba: %v, pero se obtuvo %v", result[i], v)
+		}
+	}
+
+	err = crud.New(credito{}, map[string]interface{}{"s_capi": "-100001", "s_inte": "1", "s_mora": "100"}).Insert()
+	var errs basicgorm.ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Errorf("se esperaba errores de validación: %v", err)
+		return
+	}
+	codes := []string{}
+	for _, v := range errs {
+		codes = append(codes, v.Code)
+	}
+	if len(codes) != 2 || codes[0] != basicgorm.CodeNegative || codes[1] != basicgorm.CodePrecision {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", []string{basicgorm.CodeNegative, basicgorm.CodePrecision}, codes)
+	}
+}
@@ -0,0 +1,93 @@
+package test
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/deybin/basicgorm"
+)
+
+type auditoria struct {
+	Usuario string `db:"c_usua"`
+}
+
+type sucursalScan struct {
+	auditoria
+	Codigo    string         `db:"c_sucu"`
+	Nombre    string         `db:"l_sucu"`
+	Direccion *string        `db:"l_dire"`
+	Ubigeo    sql.NullString `db:"c_ubig"`
+	Interno   string         `db:"-"`
+}
+
+const scanQuery = `SELECT c_sucu, l_sucu, l_dire, c_ubig, c_usua, n_orde FROM (VALUES
+	('001', 'principal', NULL, NULL, 'admin', 1),
+	('002', 'secundaria', 'Av. Lima 123', '120119', 'admin', 2)
+) AS s(c_sucu, l_sucu, l_dire, c_ubig, c_usua, n_orde) WHERE n_orde >= $1 ORDER BY n_orde`
+
+func TestQueryScanAll(t *testing.T) {
+	Query := new(basicgorm.Querys)
+	var sucursales []sucursalScan
+	err := Query.SetQueryString(scanQuery, 1).Exec(basicgorm.QConfig{Database: "new_capital"}).Scan(&sucursales)
+	if err != nil {
+		t.Errorf("no se esperaba error: %s", err.Error())
+		return
+	}
+	if len(sucursales) != 2 {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", 2, len(sucursales))
+		return
+	}
+
+	/** NULL en puntero y sql.NullString */
+	if sucursales[0].Direccion != nil || sucursales[0].Ubigeo.Valid {
+		t.Errorf("se esperaba NULL: %+v", sucursales[0])
+	}
+	if sucursales[1].Direccion == nil || *sucursales[1].Direccion != "Av. Lima 123" || sucursales[1].Ubigeo.String != "120119" {
+		t.Errorf("valores incorrectos: %+v", sucursales[1])
+	}
+
+	/** campos del struct embebido */
+	if sucursales[0].Usuario != "admin" || sucursales[0].Codigo != "001" {
+		t.Errorf("valores incorrectos: %+v", sucursales[0])
+	}
+
+	r := fmt.Sprint(Query.GetUnmatchedColumns())
+	result := "[n_orde]"
+	if r != result {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", result, r)
+	}
+}
+
+func TestQueryScanOne(t *testing.T) {
+	Query := new(basicgorm.Querys)
+	sucursal := new(sucursalScan)
+	err := Query.SetQueryString(scanQuery, 2).Exec(basicgorm.QConfig{Database: "new_capital"}).ScanOne(sucursal)
+	if err != nil {
+		t.Errorf("no se esperaba error: %s", err.Error())
+		return
+	}
+	if sucursal.Codigo != "002" || sucursal.Nombre != "secundaria" {
+		t.Errorf("valores incorrectos: %+v", sucursal)
+	}
+
+	var sucursales []*sucursalScan
+	err = Query.SetQueryString(scanQuery, 1).Exec(basicgorm.QConfig{Database: "new_capital"}).ScanAll(&sucursales)
+	if err != nil || len(sucursales) != 2 || sucursales[1].Codigo != "002" {
+		t.Errorf("valores incorrectos: %v %v", sucursales, err)
+	}
+
+	err = Query.SetQueryString(scanQuery, 3).Exec(basicgorm.QConfig{Database: "new_capital"}).ScanOne(sucursal)
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", sql.ErrNoRows, err)
+	}
+}
+
+func TestQueryScanInvalidDest(t *testing.T) {
+	Query := new(basicgorm.Querys)
+	var codigo string
+	if err := Query.SetQueryString(scanQuery, 1).Exec(basicgorm.QConfig{Database: "new_capital"}).Scan(&codigo); err == nil {
+		t.Errorf("se esperaba error al escanear en un destino que no es struct")
+	}
+}
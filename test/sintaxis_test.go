@@ -289,3 +289,374 @@ func TestSintaxisContexto(t *testing.T) {
 		t.Errorf("Se esperaba: %v, pero se obtuvo %v", result, rs)
 	}
 }
+
+func TestQueryWhereGroup(t *testing.T) {
+	Query := basicgorm.Querys{
+		Table: "requ_clientes",
+	}
+	Query.Select("n_docu").Where("c_ubig", basicgorm.I, "120119").AndGroup(func(g *basicgorm.Cond) {
+		g.Where("l_clie", basicgorm.LIKE, "%juan%").OrGroup(func(g *basicgorm.Cond) {
+			g.Where("n_docu", basicgorm.IN, []interface{}{"47727049", "20060977"}).And("k_stad", basicgorm.I, 0)
+		})
+	}).Not(func(g *basicgorm.Cond) {
+		g.Where("f_naci", basicgorm.BETWEEN, []interface{}{"1994-04-04", "1994-05-04"})
+	})
+	fmt.Println("test query:", Query.GetQuery())
+
+	r := Query.GetQuery()
+	result := "SELECT n_docu FROM requ_clientes WHERE c_ubig = $1 AND (l_clie LIKE $2 OR (n_docu IN ($3,$4) AND k_stad = $5)) AND NOT (f_naci BETWEEN $6 AND $7)"
+	if r != result {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", result, r)
+	}
+}
+
+func TestQueryWhereGroupFirst(t *testing.T) {
+	Query := basicgorm.Querys{
+		Table: "requ_clientes",
+	}
+	Query.Select().WhereGroup(func(g *basicgorm.Cond) {
+		g.Where("c_ubig", basicgorm.I, "120119").Or("c_ubig", basicgorm.I, "120107")
+	}).And("k_stad", basicgorm.I, 0)
+
+	r := Query.GetQuery()
+	result := "SELECT * FROM requ_clientes WHERE (c_ubig = $1 OR c_ubig = $2) AND k_stad = $3"
+	if r != result {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", result, r)
+	}
+}
+
+func TestQueryOperators(t *testing.T) {
+	Query := basicgorm.Querys{
+		Table: "requ_clientes",
+	}
+	Query.Select().Where("f_baja", basicgorm.IS_NULL, nil).And("l_clie", basicgorm.ILIKE, "%juan%").And("n_celu", basicgorm.IS_NOT_NULL, nil).And("c_ubig", basicgorm.ANY, []string{"120119", "120107"}).And("l_mail", basicgorm.IREGEX, "@gmail\\.com$").And("l_tags", basicgorm.OVERLAP, []string{"vip"}).And("j_data", basicgorm.CONTAINS, map[string]interface{}{"activo": true})
+	fmt.Println("test query:", Query.GetQuery())
+
+	r := Query.GetQuery()
+	result := "SELECT * FROM requ_clientes WHERE f_baja IS NULL AND l_clie ILIKE $1 AND n_celu IS NOT NULL AND c_ubig = ANY ($2) AND l_mail ~* $3 AND l_tags && $4 AND j_data @> $5::jsonb"
+	if r != result {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", result, r)
+	}
+}
+
+func TestQuerySafeIdentifiers(t *testing.T) {
+	Query := basicgorm.Querys{
+		Table: "requ_clientes as a",
+	}
+	Query.SafeIdentifiers().Select("a.n_docu", "b.l_nomb nombre").Join(basicgorm.INNER, "fina_clientes b", "a.n_docu=b.n_docu").Where("a.c_ubig", basicgorm.I, "120119").GroupBy("a.n_docu", "b.l_nomb").OrderBySafe("a.n_docu", "desc", "a.n_docu", "b.l_nomb")
+	fmt.Println("test query:", Query.GetQuery())
+
+	r := Query.GetQuery()
+	result := `SELECT "a"."n_docu","b"."l_nomb" AS "nombre" FROM "requ_clientes" AS "a" INNER JOIN  "fina_clientes" AS "b" ON "a"."n_docu" = "b"."n_docu" WHERE "a"."c_ubig" = $1 GROUP BY "a"."n_docu","b"."l_nomb" ORDER BY "a"."n_docu" DESC`
+	if r != result {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", result, r)
+	}
+	if Query.GetErrors() != nil {
+		t.Errorf("no se esperaba error: %s", Query.GetErrors())
+	}
+}
+
+func TestQuerySafeIdentifiersInjection(t *testing.T) {
+	Query := basicgorm.Querys{
+		Table: "requ_clientes",
+	}
+	Query.SafeIdentifiers().Select().Where("n_docu = '1' OR 1=1 --", basicgorm.I, "1").And("c_ubig", basicgorm.I, "120119")
+	err := Query.GetErrors()
+	if err == nil {
+		t.Errorf("se esperaba error de identificador invalido: %s", Query.GetQuery())
+	}
+
+	/** la consulta con error no se ejecuta, se retorna el error del constructor sin conectarse */
+	r, execErr := Query.Exec(basicgorm.QConfig{Database: "new_capital"}).All()
+	if execErr != err || len(r) != 0 {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", err, execErr)
+	}
+
+	/** un grupo con error no deja argumentos sin utilizar */
+	Query3 := basicgorm.Querys{
+		Table: "requ_clientes",
+	}
+	Query3.SafeIdentifiers().Select().Where("c_ubig", basicgorm.I, "120119").AndGroup(func(g *basicgorm.Cond) {
+		g.Where("n_docu", basicgorm.I, "47727049").Or("l_clie; DROP TABLE requ_clientes", basicgorm.I, "x")
+	}).And("k_stad", basicgorm.I, 0)
+	r2 := Query3.GetQuery()
+	result := `SELECT * FROM "requ_clientes" WHERE "c_ubig" = $1 AND "k_stad" = $2`
+	if r2 != result || Query3.GetErrors() == nil {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", result, r2)
+	}
+
+	Query2 := basicgorm.Querys{
+		Table: "requ_clientes",
+	}
+	Query2.Select().OrderBySafe("l_pass", "asc", "n_docu", "l_clie")
+	if Query2.GetErrors() == nil {
+		t.Errorf("se esperaba error de campo no permitido: %s", Query2.GetQuery())
+	}
+}
+
+func TestQuerySubquery(t *testing.T) {
+	ventas := &basicgorm.Querys{
+		Table: "stock_ventas",
+	}
+	ventas.Select("n_docu").Where("n_year", basicgorm.I, 2024).And("c_sucu", basicgorm.I, "001")
+
+	detalle := &basicgorm.Querys{
+		Table: "stock_ventasDetalle as d",
+	}
+	detalle.Select("1").Where("d.id_venta = a.id_venta AND d.c_prod", basicgorm.I, "0101002")
+
+	Query := basicgorm.Querys{
+		Table: "requ_clientes",
+	}
+	Query.Select().Where("c_ubig", basicgorm.I, "120119").And("n_docu", basicgorm.IN, ventas).And("", basicgorm.NOT_EXISTS, detalle)
+	fmt.Println("test query:", Query.GetQuery())
+
+	r := Query.GetQuery()
+	result := "SELECT * FROM requ_clientes WHERE c_ubig = $1 AND n_docu IN (SELECT n_docu FROM stock_ventas WHERE n_year = $2 AND c_sucu = $3) AND NOT EXISTS (SELECT 1 FROM stock_ventasDetalle as d WHERE d.id_venta = a.id_venta AND d.c_prod = $4)"
+	if r != result {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", result, r)
+	}
+}
+
+func TestQueryJoinSubquery(t *testing.T) {
+	ventas := &basicgorm.Querys{
+		Table: "stock_ventas",
+	}
+	ventas.Select("c_sucu", "sum(s_tota) as s_tota").Where("n_year", basicgorm.I, 2024).GroupBy("c_sucu")
+
+	Query := basicgorm.Querys{
+		Table: "conf_sucursales as a",
+	}
+	Query.Select("a.c_sucu", "b.s_tota").JoinQuery(basicgorm.LEFT, ventas, "b", "a.c_sucu = b.c_sucu").Where("a.k_stad", basicgorm.I, 0).Where("a.c_ubig", basicgorm.I, "120119")
+	fmt.Println("test query:", Query.GetQuery())
+
+	r := Query.GetQuery()
+	result := "SELECT a.c_sucu,b.s_tota FROM conf_sucursales as a LEFT JOIN  (SELECT c_sucu,sum(s_tota) as s_tota FROM stock_ventas WHERE n_year = $1 GROUP BY c_sucu) AS b ON a.c_sucu = b.c_sucu WHERE a.c_ubig = $2"
+	if r != result {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", result, r)
+	}
+}
+
+func TestQueryWith(t *testing.T) {
+	compras := &basicgorm.Querys{
+		Table: "stock_compras a",
+	}
+	compras.Select("b.c_prod", "sum(b.s_cant) as s_cant").Join(basicgorm.INNER, "stock_comprasDetalle b", "a.id_compr = b.id_compr").Where("a.n_year", basicgorm.I, 2024).And("a.c_sucu", basicgorm.I, "001").GroupBy("b.c_prod")
+
+	ventas := &basicgorm.Querys{
+		Table: "stock_ventas a",
+	}
+	ventas.Select("b.c_prod", "sum(b.s_cant) as s_cant").Join(basicgorm.INNER, "stock_ventasDetalle b", "a.id_venta = b.id_venta").Where("a.n_year", basicgorm.I, 2024).And("a.c_sucu", basicgorm.I, "001").GroupBy("b.c_prod")
+
+	Query := basicgorm.Querys{
+		Table: "compras as c",
+	}
+	Query.With("compras", compras).With("ventas", ventas).Select("c.c_prod", "c.s_cant - coalesce(v.s_cant, 0) as s_stock").Join(basicgorm.LEFT, "ventas as v", "c.c_prod = v.c_prod").Where("c.c_prod", basicgorm.I, "0101002")
+	fmt.Println("test query:", Query.GetQuery())
+
+	r := Query.GetQuery()
+	result := "WITH compras AS (SELECT b.c_prod,sum(b.s_cant) as s_cant FROM stock_compras a INNER JOIN  stock_comprasDetalle b ON a.id_compr = b.id_compr WHERE a.n_year = $1 AND a.c_sucu = $2 GROUP BY b.c_prod), ventas AS (SELECT b.c_prod,sum(b.s_cant) as s_cant FROM stock_ventas a INNER JOIN  stock_ventasDetalle b ON a.id_venta = b.id_venta WHERE a.n_year = $3 AND a.c_sucu = $4 GROUP BY b.c_prod) SELECT c.c_prod,c.s_cant - coalesce(v.s_cant, 0) as s_stock FROM compras as c LEFT JOIN  ventas as v ON c.c_prod = v.c_prod WHERE c.c_prod = $5"
+	if r != result {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", result, r)
+	}
+}
+
+func TestQueryWithRecursive(t *testing.T) {
+	arbol := &basicgorm.Querys{}
+	arbol.SetQueryString("SELECT id, padre FROM conf_menu WHERE id = $1 UNION ALL SELECT m.id, m.padre FROM conf_menu m INNER JOIN arbol a ON m.padre = a.id", 1)
+
+	Query := basicgorm.Querys{
+		Table: "arbol",
+	}
+	Query.WithRecursive("arbol(id, padre)", arbol).Select().Where("padre", basicgorm.IS_NOT_NULL, nil)
+	fmt.Println("test query:", Query.GetQuery())
+
+	r := Query.GetQuery()
+	result := "WITH RECURSIVE arbol(id, padre) AS (SELECT id, padre FROM conf_menu WHERE id = $1 UNION ALL SELECT m.id, m.padre FROM conf_menu m INNER JOIN arbol a ON m.padre = a.id) SELECT * FROM arbol WHERE padre IS NOT NULL"
+	if r != result {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", result, r)
+	}
+}
+
+func TestQueryUnion(t *testing.T) {
+	compras := &basicgorm.Querys{
+		Table: "stock_compras a",
+	}
+	compras.Select("b.c_prod", "b.c_medi", "sum(b.s_cant) as s_cant", "0 AS action").Join(basicgorm.INNER, "stock_comprasDetalle b", "a.id_compr = b.id_compr").Where("a.k_stad", basicgorm.I, 0).And("a.n_year", basicgorm.I, 2024).And("b.c_prod", basicgorm.I, "0101002").GroupBy("b.c_prod", "b.c_medi")
+
+	ventas := &basicgorm.Querys{
+		Table: "stock_ventas a",
+	}
+	ventas.Select("b.c_prod", "b.c_medi", "sum(b.s_cant) as s_cant", "1 AS action").Join(basicgorm.INNER, "stock_ventasDetalle b", "a.id_venta = b.id_venta").Where("a.k_stad", basicgorm.I, 0).And("a.n_year", basicgorm.I, 2024).And("b.c_prod", basicgorm.I, "0101002").GroupBy("b.c_prod", "b.c_medi")
+
+	compras.Union(ventas).OrderBy("action").Limit(10)
+	fmt.Println("test query:", compras.GetQuery())
+
+	r := compras.GetQuery()
+	result := "(SELECT b.c_prod,b.c_medi,sum(b.s_cant) as s_cant,0 AS action FROM stock_compras a INNER JOIN  stock_comprasDetalle b ON a.id_compr = b.id_compr WHERE a.k_stad = $1 AND a.n_year = $2 AND b.c_prod = $3 GROUP BY b.c_prod,b.c_medi) UNION (SELECT b.c_prod,b.c_medi,sum(b.s_cant) as s_cant,1 AS action FROM stock_ventas a INNER JOIN  stock_ventasDetalle b ON a.id_venta = b.id_venta WHERE a.k_stad = $4 AND a.n_year = $5 AND b.c_prod = $6 GROUP BY b.c_prod,b.c_medi) ORDER BY action LIMIT 10"
+	if r != result {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", result, r)
+	}
+
+	clientes := &basicgorm.Querys{
+		Table: "requ_clientes",
+	}
+	bajas := &basicgorm.Querys{
+		Table: "requ_clientesBaja",
+	}
+	bajas.Select("n_docu").Where("f_baja", basicgorm.MYI, "2024-01-01")
+	clientes.Select("n_docu").Where("c_ubig", basicgorm.I, "120119").Except(bajas)
+
+	r = clientes.GetQuery()
+	result = "(SELECT n_docu FROM requ_clientes WHERE c_ubig = $1) EXCEPT (SELECT n_docu FROM requ_clientesBaja WHERE f_baja >= $2)"
+	if r != result {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", result, r)
+	}
+}
+
+func TestQueryHaving(t *testing.T) {
+	Query := basicgorm.Querys{
+		Table: "stock_ventasDetalle",
+	}
+	Query.Select("c_prod", "sum(s_cant) as s_cant").Where("n_year", basicgorm.I, 2024).GroupBy("c_prod").Having("sum(s_cant)", basicgorm.MY, 100).OrHaving("count(*)", basicgorm.MYI, 10).OrderBy("c_prod")
+	fmt.Println("test query:", Query.GetQuery())
+
+	r := Query.GetQuery()
+	result := "SELECT c_prod,sum(s_cant) as s_cant FROM stock_ventasDetalle WHERE n_year = $1 GROUP BY c_prod HAVING sum(s_cant) > $2 OR count(*) >= $3 ORDER BY c_prod"
+	if r != result {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", result, r)
+	}
+
+	Query2 := basicgorm.Querys{
+		Table: "stock_ventasDetalle",
+	}
+	Query2.SafeIdentifiers().Select("c_prod").GroupBy("c_prod").Having("sum(s_cant)", basicgorm.MY, 100)
+
+	r = Query2.GetQuery()
+	result = `SELECT "c_prod" FROM "stock_ventasDetalle" GROUP BY "c_prod" HAVING sum("s_cant") > $1`
+	if r != result {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", result, r)
+	}
+}
+
+func TestQueryHavingReset(t *testing.T) {
+	Query := basicgorm.Querys{
+		Table: "stock_ventasDetalle",
+	}
+	Query.Select("c_prod").Where("n_year", basicgorm.I, 2024).GroupBy("c_prod").Having("sum(s_cant)", basicgorm.MY, 5).Having("sum(s_cant)", basicgorm.MY, 7)
+
+	/** el segundo Having reemplaza al primero junto con su argumento */
+	r := Query.GetQuery()
+	result := "SELECT c_prod FROM stock_ventasDetalle WHERE n_year = $1 GROUP BY c_prod HAVING sum(s_cant) > $2"
+	if r != result {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", result, r)
+	}
+
+	/** al utilizarla como subconsulta solo se agregan sus dos argumentos */
+	productos := basicgorm.Querys{
+		Table: "stock_productos",
+	}
+	productos.Select("c_prod").Where("c_prod", basicgorm.IN, &Query).And("k_stad", basicgorm.I, 0)
+	r = productos.GetQuery()
+	result = "SELECT c_prod FROM stock_productos WHERE c_prod IN (" + result + ") AND k_stad = $3"
+	if r != result {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", result, r)
+	}
+
+	_, err := Query.Exec(basicgorm.QConfig{Database: "new_capital"}).All()
+	if err != nil {
+		t.Errorf("no se esperaba error: %s", err.Error())
+	}
+}
+
+func TestQueryAggregate(t *testing.T) {
+	Query := basicgorm.Querys{
+		Table: "stock_ventas",
+	}
+	total, err := Query.Where("n_year", basicgorm.I, 2024).Sum(basicgorm.QConfig{Database: "new_capital"}, "s_tota")
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println("total:", total)
+
+	/** SumNumeric y AvgNumeric son exactos, no pasan por float64 */
+	montos := new(basicgorm.Querys).SetQueryString("SELECT * FROM (VALUES (0.1::numeric), (0.2::numeric)) AS v(s_tota)")
+	exacto, err := montos.SumNumeric(basicgorm.QConfig{Database: "new_capital"}, "s_tota")
+	if err != nil {
+		t.Errorf("no se esperaba error: %s", err.Error())
+		return
+	}
+	if exacto.String() != "0.3" {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", "0.3", exacto)
+	}
+	promedio, err := montos.AvgNumeric(basicgorm.QConfig{Database: "new_capital"}, "s_tota")
+	if err != nil || promedio.Cmp(basicgorm.MustParseNumeric("0.15")) != 0 {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v %v", "0.15", promedio, err)
+	}
+}
+
+func TestQueryAggregateHaving(t *testing.T) {
+	/** HAVING sin GROUP BY se aplica sobre el resultado de la consulta junto con sus argumentos */
+	Query := new(basicgorm.Querys).SetTable("stock_ventas").Select("sum(s_tota) as s_tota").Where("n_year", basicgorm.I, 2024).Having("count(*)", basicgorm.MY, 0)
+	_, err := Query.Max(basicgorm.QConfig{Database: "new_capital"}, "s_tota")
+	if err != nil {
+		t.Errorf("no se esperaba error: %s", err.Error())
+	}
+}
+
+func TestQueryPaginate(t *testing.T) {
+	query := new(basicgorm.Querys).SetTable("requ_clientes").Select("n_docu", "l_clie").Where("c_ubig", basicgorm.I, "120119").OrderBy("n_docu")
+	page, err := query.Paginate(basicgorm.QConfig{Database: "new_capital"}, 1, 10)
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(page.Total, page.Pages, page.HasNext, len(page.Rows))
+
+	page, err = query.PaginateAfter(basicgorm.QConfig{Database: "new_capital"}, 10, "47727049")
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(page.After, page.HasNext, len(page.Rows))
+
+	r := query.GetQuery()
+	result := "SELECT n_docu,l_clie FROM requ_clientes WHERE c_ubig = $1 ORDER BY n_docu"
+	if r != result {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %v", result, r)
+	}
+}
+
+func TestQueryPaginateAfterNumeric(t *testing.T) {
+	/** los valores de After se convierten al tipo de su columna, NUMERIC se retorna como Numeric y no como []byte */
+	config := basicgorm.QConfig{Database: "new_capital"}
+	query := new(basicgorm.Querys).SetTable("stock_ventas").Select("s_tota").OrderBy("s_tota")
+	page, err := query.PaginateAfter(config, 1)
+	if err != nil {
+		t.Errorf("no se esperaba error: %s", err.Error())
+		return
+	}
+	if len(page.After) == 0 {
+		return
+	}
+	if _, ok := page.After[0].(basicgorm.Numeric); !ok {
+		t.Errorf("Se esperaba: %v, pero se obtuvo %T", "basicgorm.Numeric", page.After[0])
+	}
+	if _, err := query.PaginateAfter(config, 1, page.After...); err != nil {
+		t.Errorf("no se esperaba error: %s", err.Error())
+	}
+}
+
+func TestQueryPaginateValidation(t *testing.T) {
+	config := basicgorm.QConfig{Database: "new_capital"}
+	if _, err := new(basicgorm.Querys).SetTable("requ_clientes").Select().Paginate(config, 0, 10); err == nil {
+		t.Errorf("se esperaba un error con la pagina 0")
+	}
+	if _, err := new(basicgorm.Querys).SetTable("requ_clientes").Select().PaginateAfter(config, 10); err == nil {
+		t.Errorf("se esperaba un error sin ORDER BY")
+	}
+	if _, err := new(basicgorm.Querys).SetTable("requ_clientes").Select().OrderBy("lower(l_clie)").PaginateAfter(config, 10); err == nil {
+		t.Errorf("se esperaba un error con una expresión en el ORDER BY")
+	}
+	if _, err := new(basicgorm.Querys).SetTable("requ_clientes").Select().OrderBy("f_regi DESC", "n_docu").PaginateAfter(config, 10, "2024-01-01"); err == nil {
+		t.Errorf("se esperaba un error con la cantidad de valores")
+	}
+}
@@ -0,0 +1,111 @@
+package basicgorm
+
+import (
+	"fmt"
+	"strings"
+)
+
+/** códigos de los errores de validación de los campos del esquema */
+const (
+	CodeRequired      = "required"       //El campo es requerido y no se envió valor
+	CodeEmpty         = "empty"          //El campo no acepta valor vació
+	CodeNotUpdatable  = "not_updatable"  //El campo no puede ser modificado
+	CodeNotFilterable = "not_filterable" //El campo no puede ser utilizado en el WHERE de update o delete
+	CodeInvalidType   = "invalid_type"   //El valor no es del tipo de dato del campo
+	CodeUnsupported   = "unsupported"    //El campo tiene un tipo de dato no soportado
+	CodeRegex         = "regex"          //El valor no cumple con la expresión regular
+	CodeDate          = "date"           //El valor no es una fecha valida
+	CodeMinLength     = "min_length"     //El valor tiene menos caracteres que los permitidos
+	CodeMaxLength     = "max_length"     //El valor tiene mas caracteres que los permitidos
+	CodeMin           = "min"            //El valor es menor al permitido
+	CodeMax           = "max"            //El valor es mayor al permitido
+	CodeNegative      = "negative"       //El valor no puede ser negativo
+	CodeOutOfRange    = "out_of_range"   //El valor no esta en el rango permitido
+	CodeMaxSize       = "max_size"       //El contenido supera el tamaño máximo en bytes
+	CodeContentType   = "content_type"   //El tipo de contenido no esta permitido
+	CodePrecision     = "precision"      //El numero tiene mas dígitos enteros que los permitidos por la precisión y escala
+	CodeMaxDepth      = "max_depth"      //El JSON tiene mas niveles anidados que los permitidos
+	CodeJSONRequired  = "json_required"  //Falta una llave obligatoria del JSON, params: path
+	CodeJSONType      = "json_type"      //Un valor del JSON no es del tipo esperado, params: path y type
+)
+
+/*
+ValidationError error de validación de un campo del esquema.
+
+Code es estable y pensado para ser interpretado por el frontend, Params contiene los valores de la regla incumplida
+(por ejemplo {"min": 3} para min_length) y Message el texto listo para mostrar en el idioma por defecto (ver SetCatalog y Translate).
+*/
+type ValidationError struct {
+	Field       string                 `json:"field"`            //Nombre del campo (Fields.Name)
+	Description string                 `json:"description"`      //Descripción del campo (Fields.Description)
+	Code        string                 `json:"code"`             //Código de la regla incumplida
+	Params      map[string]interface{} `json:"params,omitempty"` //Valores de la regla incumplida
+	Message     string                 `json:"message"`          //Mensaje del error
+}
+
+func (e ValidationError) Error() string {
+	return e.Message
+}
+
+/*
+ValidationErrors errores de validación de los campos de un registro, retornado por Insert, Update, Delete, Upsert y BulkCopy.
+
+Ejemplo de uso:
+
+	var errs basicgorm.ValidationErrors
+	if errors.As(err, &errs) {
+		json.NewEncoder(w).Encode(errs)
+	}
+*/
+type ValidationErrors []ValidationError
+
+/** Error retorna los mensajes numerados, uno por linea (1.- El campo ... es Requerido) */
+func (e ValidationErrors) Error() string {
+	var msg strings.Builder
+	for i, v := range e {
+		fmt.Fprintf(&msg, "%d.- %s\n", i+1, v.Message)
+	}
+	return msg.String()
+}
+
+/** Field retorna los errores del campo recibido */
+func (e ValidationErrors) Field(name string) ValidationErrors {
+	var errs ValidationErrors
+	for _, v := range e {
+		if v.Field == name {
+			errs = append(errs, v)
+		}
+	}
+	return errs
+}
+
+/** agrega los errores de la regla al campo completando su nombre, descripción y mensaje */
+func (e *ValidationErrors) add(field Fields, code string, params map[string]interface{}) {
+	*e = append(*e, ValidationError{
+		Field:       field.Name,
+		Description: field.Description,
+		Code:        code,
+		Params:      params,
+		Message:     fieldMessage(GetCatalog(""), field.Description, code, params),
+	})
+}
+
+/** agrega al campo los errores retornados por las validaciones del tipo de dato */
+func (e *ValidationErrors) addAll(field Fields, rules []ValidationError) {
+	for _, rule := range rules {
+		e.add(field, rule.Code, rule.Params)
+	}
+}
+
+/** retorna nil si no existen errores, evita retornar un error con valor nil */
+func (e ValidationErrors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+/** regla incumplida por el valor, el campo se completa al agregarla con ValidationErrors.addAll */
+func rule(code string, params map[string]interface{}) ValidationError {
+	return ValidationError{Code: code, Params: params}
+}